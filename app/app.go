@@ -13,6 +13,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	bapp "github.com/sharering/shareledger/cosmos-wrapper/baseapp"
+	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
 
 	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
@@ -22,6 +23,8 @@ import (
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/bank"
 	"github.com/sharering/shareledger/x/booking"
+	"github.com/sharering/shareledger/x/dispute"
+	"github.com/sharering/shareledger/x/reputation"
 	"github.com/sharering/shareledger/x/exchange"
 	"github.com/sharering/shareledger/x/fee"
 	"github.com/sharering/shareledger/x/pos"
@@ -48,11 +51,14 @@ type ShareLedgerApp struct {
 	//accountKey *sdk.KVStoreKey
 
 	//keepers
-	bankKeeper     bank.Keeper
-	posKeeper      pKeeper.Keeper
-	bookingKeeper  booking.Keeper
-	assetKeeper    asset.Keeper
-	exchangeKeeper exchange.Keeper
+	bankKeeper       bank.Keeper
+	posKeeper        pKeeper.Keeper
+	bookingKeeper    booking.Keeper
+	disputeKeeper    dispute.Keeper
+	reputationKeeper reputation.Keeper
+	assetKeeper      asset.Keeper
+	exchangeKeeper   exchange.Keeper
+	feeKeeper        fee.Keeper
 
 	// Manage getting and setting accounts
 	accountMapper auth.AccountMapper
@@ -71,7 +77,10 @@ func NewShareLedgerApp(logger log.Logger, db dbm.DB) *ShareLedgerApp {
 	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
 	posKey := sdk.NewKVStoreKey(constants.STORE_POS)
 	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
-	//bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	disputeKey := sdk.NewKVStoreKey(constants.STORE_DISPUTE)
+	reputationKey := sdk.NewKVStoreKey(constants.STORE_REPUTATION)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	feeKey := sdk.NewKVStoreKey(constants.STORE_FEE)
 
 	// accountMapper for Auth Module storing and Bank module
 	accountMapper := auth.NewAccountMapper(
@@ -89,36 +98,41 @@ func NewShareLedgerApp(logger log.Logger, db dbm.DB) *ShareLedgerApp {
 		assetKey:   assetKey,
 		bookingKey: bookingKey,
 		posKey:     posKey,
+		bankKey:    bankKey,
 		//accountKey:    accountKey,
 		accountMapper: accountMapper,
 	}
-	app.SetupAsset(assetKey)
-	app.SetupBank(accountMapper)
+	app.SetupBank(bankKey, accountMapper) // other modules move coins through the bank keeper
 	app.SetupPOS(posKey, accountMapper)
+	app.SetupExchange(exchangeKey, accountMapper) // booking keeper converts payments through the exchange
 	app.SetupBooking(bookingKey, assetKey, accountMapper)
-	app.SetupExchange(exchangeKey, accountMapper)
+	app.SetupAsset(assetKey) // asset keeper checks bookings, booking keeper comes first
+	app.SetupDispute(disputeKey, accountMapper)
+	app.SetupReputation(reputationKey)
+	app.SetupFee(feeKey)
 
 	//app.SetTxDecoder(auth.GetTxDecoder(cdc))
-	app.SetAnteHandler(auth.NewAnteHandler(accountMapper))
+	// the gas of the ante handler is charged even if messages fail
+	app.SetAnteHandler(fee.NewAnteHandler(auth.NewAnteHandler(accountMapper), app.bankKeeper, app.posKeeper, exchangeKey))
 
-	// app.Router().
-	// AddRoute(constants.MESSAGE_AUTH, auth.NewHandler(accountMapper))
+	// grants are given and revoked through the auth module
+	app.AddRoute(constants.MESSAGE_AUTH, auth.NewHandler(accountMapper))
 
 	app.QueryRouter().
 		AddRoute(constants.MESSAGE_AUTH, auth.NewQuerier(accountMapper, app.cdc))
 	app.cdc = auth.RegisterCodec(app.cdc)
 
 	// Set Tx Fee Calculation
-	app.SetFeeHandler(fee.NewFeeHandler(accountMapper, exchangeKey))
+	app.SetFeeHandler(fee.NewFeeHandler(app.feeKeeper, app.bankKeeper, app.posKeeper, exchangeKey))
 
 	// Register InitChain
 	logger.Info("Register Init Chainer")
 	app.SetInitChainer(app.InitChainer)
-	app.SetEndBlocker(EndBlocker(accountMapper, app.posKeeper))
-	app.SetBeginBlocker(BeginBlocker)
+	app.SetEndBlocker(EndBlocker(accountMapper, app.posKeeper, app.bookingKeeper, app.disputeKeeper, app.bankKeeper))
+	app.SetBeginBlocker(BeginBlocker(app.bankKeeper))
 
 	//  Mount Store
-	baseApp.MountStores(authKey, assetKey, bookingKey, posKey, exchangeKey) //replace baseApp.MountStoresIAVL
+	baseApp.MountStores(authKey, assetKey, bookingKey, posKey, exchangeKey, disputeKey, reputationKey, bankKey, feeKey) //replace baseApp.MountStoresIAVL
 	err := baseApp.LoadLatestVersion(authKey)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -160,6 +174,18 @@ func (app *ShareLedgerApp) InitChainer(ctx sdk.Context, req abci.RequestInitChai
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// load the issuers
+	err = bank.InitGenesis(ctx, app.bankKeeper, genesisState.Bank)
+	if err != nil {
+		panic(err)
+	}
+
+	// load the fee schedule
+	err = fee.InitGenesis(ctx, app.feeKeeper, genesisState.Fees)
+	if err != nil {
+		panic(err)
+	}
+
 	// load the initial POS information
 	abciVals, err := pos.InitGenesis(ctx, app.posKeeper, genesisState.StakeData)
 	if err != nil {
@@ -179,27 +205,50 @@ func (app *ShareLedgerApp) InitChainer(ctx sdk.Context, req abci.RequestInitChai
 
 }
 
-func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
+// CheckSupply - check the total supply against accounts at the last committed height
+func (app *ShareLedgerApp) CheckSupply() error {
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	return app.bankKeeper.CheckSupply(ctx)
+}
+
+func BeginBlocker(bankKeeper bank.Keeper) sdk.BeginBlocker {
+	return func(ctx sdk.Context, req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
+
+		// Save BlockHeader and Height to Context
+		ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 
-	// Save BlockHeader and Height to Context
-	ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
+		// Reset this variable at the beginning of a block
+		pos.ValidatorChanged = false
 
-	// Reset this variable at the beginning of a block
-	pos.ValidatorChanged = false
+		//fmt.Printf("BeginBlocker: %v\n", req.Header.Proposer)
 
-	//fmt.Printf("BeginBlocker: %v\n", req.Header.Proposer)
+		// before any transaction changes the supply
+		bank.BeginBlocker(ctx, bankKeeper)
 
-	return
+		return
+	}
 }
 
 // application updates every end block
-func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper) sdk.EndBlocker {
+func EndBlocker(
+	am auth.AccountMapper,
+	keeper pKeeper.Keeper,
+	bookingKeeper booking.Keeper,
+	disputeKeeper dispute.Keeper,
+	bankKeeper bank.Keeper,
+) sdk.EndBlocker {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 
+		resTags := booking.EndBlocker(ctx, bookingKeeper)
+		resTags = resTags.AppendTags(dispute.EndBlocker(ctx, disputeKeeper))
+
 		proposer := ctx.BlockHeader().ProposerAddress //.Proposer
 
 		validatorUpdates := pos.EndBlocker(ctx, keeper, proposer)
 
+		// every balance of the block is settled, check the supply last
+		bank.EndBlocker(ctx, bankKeeper)
+
 		for _, val := range validatorUpdates {
 			constants.LOGGER.Info("Validator Update",
 				// "Address", fmt.Sprintf("%X", val.Address),
@@ -211,6 +260,7 @@ func EndBlocker(am auth.AccountMapper, keeper pKeeper.Keeper) sdk.EndBlocker {
 		// Add these new validators to the addr -> pubkey map.
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: validatorUpdates,
+			Tags:             resTags,
 		}
 	}
 }
@@ -225,45 +275,51 @@ func MakeCodec() *amino.Codec {
 	cdc.RegisterInterface((*types.SHRSignature)(nil), nil)
 	cdc.RegisterConcrete(types.BasicSig{}, "shareledger/BasicSig", nil)
 	cdc.RegisterConcrete(auth.AuthSig{}, "shareledger/AuthSig", nil)
+	cdc.RegisterConcrete(auth.AuthMultiSig{}, "shareledger/AuthMultiSig", nil)
 
 	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
 	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
 
 	cdc.RegisterInterface((*types.PubKey)(nil), nil)
 	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+	cdc.RegisterConcrete(types.PubKeyMultisig{}, "shareledger/PubMultisig", nil)
 
 	cdc.RegisterInterface((*types.Signature)(nil), nil)
 	cdc.RegisterConcrete(types.SignatureSecp256k1{}, "shareledger/SigSecp256k1", nil)
+	cdc.RegisterConcrete(types.SignatureMultisig{}, "shareledger/SigMultisig", nil)
 
 	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
 	return cdc
 }
 
-func (app *ShareLedgerApp) SetupBank(am auth.AccountMapper) {
+func (app *ShareLedgerApp) SetupBank(bankKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	// Bank module
-	// Create a key for accessing the account store.
+	// Balances are kept in the account store, issuers and the supply ledger in the bank store.
 	app.cdc = bank.RegisterCodec(app.cdc)
-	app.bankKeeper = bank.NewKeeper(am /*, cdc*/)
+	app.bankKeeper = bank.NewKeeper(am, bankKey, app.cdc)
 	// Register message routes.
 	// Note the handler gets access to the account store.
 
-	app.AddRoute("bank", bank.NewHandler(am))
+	app.AddRoute("bank", bank.NewHandler(app.bankKeeper))
 	// app.Router().
 	// 	AddRoute("bank", bank.NewHandler(am))
 
 	app.QueryRouter().
-		AddRoute(constants.MESSAGE_BANK, bank.NewQuerier(am, app.cdc))
+		AddRoute(constants.MESSAGE_BANK, bank.NewQuerier(app.bankKeeper, app.cdc))
 
 }
 
 func (app *ShareLedgerApp) SetupAsset(assetKey *sdk.KVStoreKey) {
 
-	keeper := asset.NewKeeper(assetKey, app.cdc)
+	app.assetKeeper = asset.NewKeeper(assetKey, app.bookingKeeper, app.cdc)
 
 	app.cdc = asset.RegisterCodec(app.cdc)
 
-	app.Router().
-		AddRoute("asset", asset.NewHandler(keeper))
+	// assets pay no fee of their own, only for the gas they use
+	app.AddRoute("asset", sdkTypes.WrapHandler(asset.NewHandler(app.assetKeeper)))
+
+	app.QueryRouter().
+		AddRoute(constants.MESSAGE_ASSET, asset.NewQuerier(app.assetKeeper, app.cdc))
 
 	// app.MountStoresIAVL(assetKey)
 }
@@ -275,13 +331,17 @@ func (app *ShareLedgerApp) SetupBooking(bookingKey *sdk.KVStoreKey,
 
 	app.bookingKeeper = booking.NewKeeper(bookingKey,
 		assetKey,
-		am,
+		app.bankKeeper,
+		app.exchangeKeeper,
 		app.cdc)
 
 	// app.Router().
 	// AddRoute("booking", booking.NewHandler(app.bookingKeeper))
 
 	app.AddRoute("booking", booking.NewHandler(app.bookingKeeper))
+
+	app.QueryRouter().
+		AddRoute(constants.MESSAGE_BOOKING, booking.NewQuerier(app.bookingKeeper, app.cdc))
 	// app.MountStoresIAVL(bookingKey)
 
 }
@@ -289,9 +349,9 @@ func (app *ShareLedgerApp) SetupBooking(bookingKey *sdk.KVStoreKey,
 func (app *ShareLedgerApp) SetupPOS(posKey *sdk.KVStoreKey,
 	am auth.AccountMapper) {
 	app.cdc = pos.RegisterCodec(app.cdc)
-	bankKeeper := bank.NewKeeper(am)
-	app.posKeeper = pKeeper.NewKeeper(posKey, bankKeeper, app.cdc)
-	app.Router().AddRoute("pos", pos.NewHandler(app.posKeeper))
+	app.posKeeper = pKeeper.NewKeeper(posKey, app.bankKeeper, app.cdc)
+	// staking messages pay for the gas they use like any other
+	app.AddRoute("pos", sdkTypes.WrapHandler(pos.NewHandler(app.posKeeper)))
 	app.QueryRouter().
 		AddRoute("pos", pos.NewQuerier(app.posKeeper, app.cdc))
 
@@ -301,9 +361,40 @@ func (app *ShareLedgerApp) SetupPOS(posKey *sdk.KVStoreKey,
 
 func (app *ShareLedgerApp) SetupExchange(exchangeKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	app.cdc = exchange.RegisterCodec(app.cdc)
-	bankKeeper := bank.NewKeeper(am)
-	app.exchangeKeeper = exchange.NewKeeper(exchangeKey, bankKeeper)
+	app.exchangeKeeper = exchange.NewKeeper(exchangeKey, app.bankKeeper)
 
 	app.AddRoute("exchangerate", exchange.NewHandler(app.exchangeKeeper))
 	// app.Router().AddRoute("exchangerate", exchange.NewHandler(app.exchangeKeeper))
 }
+
+func (app *ShareLedgerApp) SetupDispute(disputeKey *sdk.KVStoreKey, am auth.AccountMapper) {
+	app.cdc = dispute.RegisterCodec(app.cdc)
+	app.disputeKeeper = dispute.NewKeeper(disputeKey, app.bookingKeeper, app.bankKeeper, app.cdc)
+
+	app.AddRoute(constants.MESSAGE_DISPUTE, dispute.NewHandler(app.disputeKeeper))
+
+	app.QueryRouter().
+		AddRoute(constants.MESSAGE_DISPUTE, dispute.NewQuerier(app.disputeKeeper, app.cdc))
+}
+
+func (app *ShareLedgerApp) SetupReputation(reputationKey *sdk.KVStoreKey) {
+	app.cdc = reputation.RegisterCodec(app.cdc)
+	app.reputationKeeper = reputation.NewKeeper(reputationKey, app.bookingKeeper, app.cdc)
+
+	app.AddRoute(constants.MESSAGE_REPUTATION, reputation.NewHandler(app.reputationKeeper))
+
+	app.QueryRouter().
+		AddRoute(constants.MESSAGE_REPUTATION, reputation.NewQuerier(app.reputationKeeper, app.cdc))
+}
+
+func (app *ShareLedgerApp) SetupFee(feeKey *sdk.KVStoreKey) {
+	app.cdc = fee.RegisterCodec(app.cdc)
+
+	// the fee handler reads message fees from the fee schedule
+	app.feeKeeper = fee.NewKeeper(feeKey, app.cdc)
+
+	app.AddRoute(constants.MESSAGE_FEE, fee.NewHandler(app.feeKeeper))
+
+	app.QueryRouter().
+		AddRoute(constants.MESSAGE_FEE, fee.NewQuerier(app.feeKeeper, app.cdc))
+}
@@ -4,13 +4,17 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/fee"
 	"github.com/sharering/shareledger/x/pos"
 )
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount `json:"accounts"`
-	StakeData pos.GenesisState `json:"stake"`
+	Accounts  []GenesisAccount  `json:"accounts"`
+	StakeData pos.GenesisState  `json:"stake"`
+	Bank      bank.GenesisState `json:"bank"`
+	Fees      fee.GenesisState  `json:"fees"`
 }
 
 func (gs *GenesisState) ToJSON() []byte {
@@ -48,5 +52,7 @@ func (ga *GenesisAccount) ToSHRAccount() (acc *auth.SHRAccount) {
 func GenerateGenesisState(pubKey types.PubKeySecp256k1) GenesisState {
 	return GenesisState{
 		StakeData: pos.GenerateGenesis(pubKey),
+		Bank:      bank.GenerateGenesis(),
+		Fees:      fee.GenerateGenesis(),
 	}
 }
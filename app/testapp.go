@@ -40,11 +40,11 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 	baseApp := bapp.NewBaseApp(appName, logger, db, auth.GetTxDecoder(cdc))
 
 	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
-	//bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
 
 	// Mount Store
 
-	baseApp.MountStores(authKey)
+	baseApp.MountStores(authKey, bankKey)
 	err := baseApp.LoadLatestVersion(authKey)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -73,7 +73,7 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 		// AddRoute(constants.MESSAGE_AUTH, auth.NewHandler(accountMapper))
 	app.cdc = auth.RegisterCodec(app.cdc)
 
-	app.SetupBank(accountMapper)
+	app.SetupBank(bankKey, accountMapper)
 
 	// Set Tx Fee Calculation
 	// app.SetFeeHandler(fee.NewFeeHandler(accountMapper, exchangeKey))
@@ -87,11 +87,11 @@ func NewTestShareLedgerApp(logger log.Logger, db dbm.DB) *TestShareLedgerApp {
 	return app
 }
 
-func (app *TestShareLedgerApp) SetupBank(am auth.AccountMapper) {
+func (app *TestShareLedgerApp) SetupBank(bankKey *sdk.KVStoreKey, am auth.AccountMapper) {
 	// Bank module
 	// Create a key for accessing the account store.
 	app.cdc = bank.RegisterCodec(app.cdc)
-	app.bankKeeper = bank.NewKeeper(am /*, cdc*/)
+	app.bankKeeper = bank.NewKeeper(am, bankKey, app.cdc)
 	// Register message routes.
 	// Note the handler gets access to the account store.
 	// app.Router().
@@ -68,6 +68,69 @@ func (c CoreContext) ConstructTransaction(msg sdk.Msg) (auth.AuthTx, error) {
 	return authTx, nil
 }
 
+// ConstructMultiMsgTransaction - sign several messages executed in order, all or none
+func (c CoreContext) ConstructMultiMsgTransaction(msgs []sdk.Msg) (auth.AuthTx, error) {
+	nonce, err := c.GetNonce()
+	if err != nil {
+		return auth.AuthTx{}, err
+	}
+
+	authTx := auth.GetMultiMsgAuthTx(c.PrivKey.PubKey(), c.PrivKey, msgs, nonce+1)
+	return authTx, nil
+}
+
+// ConstructGasTransaction - sign *msg* with its gas limit and gas price, large transactions need more gas
+func (c CoreContext) ConstructGasTransaction(msg sdk.Msg, gasLimit uint64, gasPrice int64) (auth.AuthTx, error) {
+	nonce, err := c.GetNonce()
+	if err != nil {
+		return auth.AuthTx{}, err
+	}
+
+	tx := auth.NewAuthTx(msg, auth.AuthSig{}).WithGas(gasLimit, gasPrice)
+
+	sig := c.PrivKey.SignBytesWithNonce(tx.GetSignBytes(), nonce+1)
+	tx.Signature = auth.NewAuthSig(c.PrivKey.PubKey(), sig, nonce+1)
+	return tx, nil
+}
+
+// SignPartial - share of this key in the signature of *tx* by a multisig account.
+// Made offline, the multisig account nonce is given by the caller.
+func (c CoreContext) SignPartial(tx auth.AuthTx, nonce int64) auth.AuthSig {
+	sig := c.PrivKey.SignBytesWithNonce(tx.GetSignBytes(), nonce)
+	return auth.NewAuthSig(c.PrivKey.PubKey(), sig, nonce)
+}
+
+// AssembleMultiSig - sign *tx* for the multisig account of *pubKey* with the partial signatures of its keys
+func AssembleMultiSig(tx auth.AuthTx, pubKey types.PubKeyMultisig, sigs []auth.AuthSig, nonce int64) (auth.AuthTx, error) {
+	signed := tx.WithMultiSig(auth.NewAuthMultiSig(pubKey, sigs, nonce))
+
+	if _, err := signed.MultiSig.Assemble(); err != nil {
+		return auth.AuthTx{}, err
+	}
+
+	if !signed.VerifySignature() {
+		return auth.AuthTx{}, fmt.Errorf("%d partial signatures don't verify for %d of %d keys",
+			len(sigs), pubKey.Threshold, len(pubKey.PubKeys))
+	}
+	return signed, nil
+}
+
+// BroadcastTransaction - send an already signed transaction
+func (c CoreContext) BroadcastTransaction(tx auth.AuthTx) (string, error) {
+	tdmTx, err := c.ConstructTendermintTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.Client.BroadcastTxCommit(tdmTx)
+	if err != nil {
+		return "", err
+	}
+
+	err, output := processTDMResponse(r)
+	return output, err
+}
+
 // ConstructTendermintTransaction - encode a ShareLedger authTx in Amino and form a Tendermint tx
 // before sending to ShareLedger
 func (c CoreContext) ConstructTendermintTransaction(tx auth.AuthTx) (tdmtx tdmtypes.Tx, err error) {
@@ -30,6 +30,10 @@ func main() {
 		subcommands.WithdrawBlockRewardCmd,
 		subcommands.BeginUnbondingCmd,
 		subcommands.CompleteUnbondingCmd,
+		subcommands.MultisigAddressCmd,
+		subcommands.MultisigSignCmd,
+		subcommands.MultisigAssembleCmd,
+		subcommands.CheckSupplyCmd,
 	)
 
 	rootCmd.Execute()
@@ -0,0 +1,49 @@
+package subcommands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/sharering/shareledger/app"
+)
+
+var (
+	dataDir string
+)
+
+// CheckSupplyCmd checks the total supply against the accounts of a stopped node
+var CheckSupplyCmd = &cobra.Command{
+	Use:   "check_supply",
+	Short: "Check the total supply matches what accounts hold. The node must be stopped",
+	RunE:  checkSupply,
+}
+
+func init() {
+	CheckSupplyCmd.Flags().StringVar(&dataDir, "data", "", "Data directory of the node. Default to data in the home directory")
+}
+
+func checkSupply(cmd *cobra.Command, args []string) error {
+	if dataDir == "" {
+		dataDir = filepath.Join(viper.GetString(HomeFlag), "data")
+	}
+
+	db, err := dbm.NewGoLevelDB("shareledgerd", dataDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	shareledgerApp := app.NewShareLedgerApp(initLogger(), db)
+
+	if err := shareledgerApp.CheckSupply(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Supply matches accounts at height %d\n", shareledgerApp.LastBlockHeight())
+	return nil
+}
@@ -0,0 +1,179 @@
+package subcommands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sharering/shareledger/client"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+// A multisig account signs offline:
+//  1. multisig_address shows the address of the key set, to fund it
+//  2. every key holder runs multisig_sign on the same unsigned transaction file
+//  3. anyone runs multisig_assemble with the partial signatures, and broadcasts the result
+var (
+	multisigThreshold int64
+	multisigPubKeys   string
+	multisigNonce     int64
+	multisigBroadcast bool
+)
+
+var MultisigAddressCmd = &cobra.Command{
+	Use:   "multisig_address",
+	Short: "Show the address of a multisig account",
+	RunE:  multisigAddress,
+}
+
+var MultisigSignCmd = &cobra.Command{
+	Use:   "multisig_sign [unsigned-tx-file]",
+	Short: "Sign a multisig account transaction with this node's key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  multisigSign,
+}
+
+var MultisigAssembleCmd = &cobra.Command{
+	Use:   "multisig_assemble [unsigned-tx-file] [partial-signature-file]...",
+	Short: "Combine partial signatures into a signed multisig account transaction",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  multisigAssemble,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{MultisigAddressCmd, MultisigAssembleCmd} {
+		cmd.Flags().Int64Var(&multisigThreshold, "threshold", 1, "Number of keys which have to sign")
+		cmd.Flags().StringVar(&multisigPubKeys, "pubkeys", "", "Comma separated hex public keys, in order")
+	}
+
+	for _, cmd := range []*cobra.Command{MultisigSignCmd, MultisigAssembleCmd} {
+		cmd.Flags().Int64Var(&multisigNonce, "nonce", 0, "Nonce of the multisig account for this transaction, its current nonce + 1")
+	}
+
+	MultisigAssembleCmd.Flags().BoolVar(&multisigBroadcast, "broadcast", false, "Broadcast the signed transaction instead of printing it")
+	MultisigAssembleCmd.Flags().StringVar(&nodeAddress, "client", "", "Node address to broadcast to. Example: tcp://127.0.0.1:46657")
+}
+
+func multisigAddress(cmd *cobra.Command, args []string) error {
+	pubKey, err := parseMultisigPubKey()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", pubKey.Address().String())
+	return nil
+}
+
+func multisigSign(cmd *cobra.Command, args []string) error {
+	context := client.NewCoreContextFromConfig(config)
+
+	tx, err := readUnsignedTx(context, args[0])
+	if err != nil {
+		return err
+	}
+
+	partial := context.SignPartial(tx, multisigNonce)
+
+	bz, err := context.Codec.MarshalJSON(partial)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", bz)
+	return nil
+}
+
+func multisigAssemble(cmd *cobra.Command, args []string) error {
+	var context client.CoreContext
+	if nodeAddress == "" {
+		context = client.NewCoreContextFromConfig(config)
+	} else {
+		context = client.NewCoreContextFromConfigWithClient(config, nodeAddress)
+	}
+
+	pubKey, err := parseMultisigPubKey()
+	if err != nil {
+		return err
+	}
+
+	tx, err := readUnsignedTx(context, args[0])
+	if err != nil {
+		return err
+	}
+
+	var sigs []auth.AuthSig
+
+	for _, file := range args[1:] {
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var partial auth.AuthSig
+		if err := context.Codec.UnmarshalJSON(bz, &partial); err != nil {
+			return fmt.Errorf("Invalid partial signature in %s: %s", file, err)
+		}
+		sigs = append(sigs, partial)
+	}
+
+	signed, err := client.AssembleMultiSig(tx, pubKey, sigs, multisigNonce)
+	if err != nil {
+		return err
+	}
+
+	if multisigBroadcast {
+		output, err := context.BroadcastTransaction(signed)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", output)
+		return nil
+	}
+
+	bz, err := context.Codec.MarshalJSON(signed)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", bz)
+	return nil
+}
+
+func parseMultisigPubKey() (types.PubKeyMultisig, error) {
+	var keys []types.PubKeySecp256k1
+
+	for _, s := range strings.Split(multisigPubKeys, ",") {
+		bz, err := hex.DecodeString(strings.TrimSpace(s))
+		if err != nil || len(bz) != 65 {
+			return types.PubKeyMultisig{}, fmt.Errorf("Invalid public key %s", s)
+		}
+		keys = append(keys, types.NewPubKeySecp256k1(bz))
+	}
+
+	pubKey := types.NewPubKeyMultisig(multisigThreshold, keys)
+	if err := pubKey.Validate(); err != nil {
+		return types.PubKeyMultisig{}, err
+	}
+	return pubKey, nil
+}
+
+// readUnsignedTx reads an AuthTx in JSON, whose signature is ignored
+func readUnsignedTx(context client.CoreContext, file string) (auth.AuthTx, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return auth.AuthTx{}, err
+	}
+
+	var tx auth.AuthTx
+	if err := context.Codec.UnmarshalJSON(bz, &tx); err != nil {
+		return auth.AuthTx{}, fmt.Errorf("Invalid transaction in %s: %s", file, err)
+	}
+
+	tx.Signature = auth.AuthSig{}
+	tx.MultiSig = nil
+	return tx, nil
+}
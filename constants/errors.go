@@ -12,14 +12,104 @@ const ERROR_STORE_NOT_FOUND = "%s cannot be found in Store %s."
 // NOT FOUND
 const BOOKING_MISMATCH_RENTER = "Mismatch renter from Booking request %s with Complete request %s"
 const BOOKING_COMPLETED_ERROR = "The booking %s is already completed."
-const BOOKING_ASSET_NOT_RENTED = "Asset %s is not rented."
-const BOOKING_ASSET_RENTED = "Asset %s is already rented."
+const BOOKING_ASSET_UNAVAILABLE = "Asset %s is not available for booking."
+const BOOKING_SLOT_TAKEN = "Asset %s is already booked from %d to %d."
+const BOOKING_INVALID_PERIOD = "Invalid booking period from %d to %d."
+const BOOKING_INVALID_DURATION = "Invalid booking duration %d."
+const BOOKING_DURATION_MISMATCH = "Booking duration %d doesn't match %d %s(s) from %d to %d."
+const BOOKING_START_PASSED = "Booking start %d is before current block time %d."
 const BOOKING_INSUFFICIENT_BALANCE = "Account %s has insuficient balance."
+const BOOKING_CANCEL_UNAUTHORIZED = "Only renter or owner of the asset can cancel booking %s. Signer %s."
+const BOOKING_CANCEL_STARTED = "Booking %s started at %d, its renter can no longer cancel it."
+const BOOKING_NOT_COMPLETED = "The booking %s is not completed yet."
+const BOOKING_CLAIM_UNAUTHORIZED = "Only owner of the asset can claim deposit of booking %s. Signer %s."
+const BOOKING_CLAIM_WINDOW_CLOSED = "Claim window of booking %s closed at %d."
+const BOOKING_CLAIM_EXCEEDS_DEPOSIT = "Claim of %s exceeds remaining deposit %s of booking %s."
+const BOOKING_INVALID_CLAIM = "Invalid deposit claim %d."
+const BOOKING_DISPUTED_ERROR = "The booking %s is under dispute."
+const BOOKING_NOTHING_HELD = "Nothing is held in escrow for booking %s."
+const BOOKING_INVALID_FILTER = "Invalid booking status filter %s."
+const BOOKING_INVALID_TRANSITION = "Booking %s cannot go from %s to %s."
+const BOOKING_CONFIRM_UNAUTHORIZED = "Booking %s can only be confirmed by the asset owner, not %s."
+const BOOKING_REJECT_UNAUTHORIZED = "Booking %s can only be rejected by the asset owner, not %s."
+const BOOKING_REQUEST_EXPIRED = "Booking request %s expired at block %d."
+const BOOKING_INVALID_MAX_PAYMENT = "Invalid maximum payment %s."
+const BOOKING_SLIPPAGE_EXCEEDED = "Booking costs %s, more than the maximum payment %s."
+const BOOKING_NO_RESERVE = "No reserve holds %s to convert the booking payment."
+const BOOKING_DURATION_NOT_ALLOWED = "Booking duration %d is out of [%d, %d] allowed by asset %s."
+const BOOKING_QUOTE_TOO_LONG = "Booking of %d units is too long to price, at most %d units have surcharges."
+const BOOKING_NO_DEVICE = "Asset %s has no device to attest check-in and check-out."
+const BOOKING_INVALID_ATTESTATION = "Invalid device attestation of %s for booking %s at height %d."
+const BOOKING_ATTESTATION_HEIGHT = "Attestation height %d is not within %d blocks before the current block %d."
+const BOOKING_CHECK_IN_EARLY = "Booking %s starts at %d, it cannot be checked in before."
+const BOOKING_NOT_CHECKED_IN = "Booking %s is not checked in."
+const BOOKING_ALREADY_CHECKED_OUT = "Booking %s is already checked out."
+const BOOKING_NOT_CHECKED_OUT = "Booking %s has to be checked out before the renter completes it."
+const BOOKING_SUBSCRIBED = "Asset %s is rented by subscription %s."
+const SUBSCRIPTION_INVALID_PERIOD = "Invalid subscription period %s."
+const SUBSCRIPTION_INVALID_MAX_AMOUNT = "Invalid maximum amount %s per period."
+const SUBSCRIPTION_EXCEEDS_MAX_AMOUNT = "Subscription costs %s per period, more than the authorized %s."
+const SUBSCRIPTION_REQUIRES_APPROVAL = "Asset %s requires owner approval and cannot be subscribed to."
+const SUBSCRIPTION_NOT_ACTIVE = "Subscription %s is %s."
+const SUBSCRIPTION_CANCEL_UNAUTHORIZED = "Subscription %s can only be cancelled by its renter or the asset owner, not %s."
+const BOOKING_ID_COLLISION = "Booking %s already exists."
+const BOOKING_ESCROW_MISMATCH = "Booking escrow holds %s while open bookings are owed %s."
+
+// ASSET
+const ASSET_INVALID_REFUND_POLICY = "Invalid refund policy %v."
+const ASSET_INVALID_DEPOSIT = "Invalid deposit %d."
+const ASSET_ALREADY_EXISTS = "Asset %s already exists."
+const ASSET_UNAUTHORIZED = "Only creator %s of asset %s can modify it. Signer %s."
+const ASSET_CREATOR_MISMATCH = "Asset creator %s must sign its creation. Signer %s."
+const ASSET_ACTIVE_BOOKING = "Asset %s has active bookings."
+const ASSET_TERMS_LOCKED = "Fee, deposit, denom, pricing unit, refund policy, booking mode, pricing rules and device of asset %s cannot change while it has active bookings."
+const ASSET_HELD_BY_LEGACY_BOOKING = "Asset %s is held by a booking made before time windows and cannot be made available until that booking ends."
+const ASSET_INVALID_METADATA = "Invalid asset %s %s."
+const ASSET_INVALID_BOOKING_MODE = "Invalid booking mode %s."
+const ASSET_INVALID_PRICING = "Invalid asset pricing %s %s."
+const ASSET_INVALID_DEVICE = "Invalid asset device public key %s."
+const ASSET_INVALID_UUID = "Invalid asset UUID %q."
+const ASSET_INVALID_PAGINATION = "Invalid page %d or limit %d."
+
+// DISPUTE
+const DISPUTE_ALREADY_EXISTS = "Booking %s is already disputed."
+const DISPUTE_NOT_PARTY = "Only renter or owner of the asset can dispute booking %s. Signer %s."
+const DISPUTE_RESOLVED = "Dispute of booking %s is already resolved."
+const DISPUTE_NOT_ARBITRATOR = "Account %s is not an arbitrator."
+const DISPUTE_ALREADY_VOTED = "Arbitrator %s has already voted on dispute of booking %s."
+const DISPUTE_INVALID_SHARE = "Invalid renter share %d. Share is a percentage from 0 to 100."
+const DISPUTE_ARBITRATOR_EXISTS = "Account %s is already an arbitrator."
+const DISPUTE_FROZEN_MISMATCH = "Dispute account holds %s, less than the %s frozen by open disputes."
 
 // SHRAccount
 const SHRACCOUNT_EXISITNG_ADDRESS = "Address already exists."
 const SHRACCOUNT_INVALID_ADDRESS = "Invalid address."
 
+// AUTH TRANSACTION
+const AUTH_EMPTY_TX = "Transaction has no message."
+const AUTH_TOO_MANY_MSGS = "Transaction has %d messages, at most %d are allowed."
+const AUTH_MSG_AND_MSGS = "Transaction has both a single message and a list of messages."
+const AUTH_SIG_AND_MULTISIG = "Transaction has both a signature and a multisig signature."
+
+// MULTISIG
+const MULTISIG_INVALID_THRESHOLD = "Threshold %d must be between 1 and the %d keys."
+const MULTISIG_TOO_MANY_KEYS = "Multisig key has %d keys, at most %d are allowed."
+const MULTISIG_INVALID_KEY = "Invalid key %s in multisig key."
+const MULTISIG_DUPLICATE_KEY = "Key %s appears more than once in multisig key."
+const MULTISIG_NOT_MEMBER = "Key %s is not part of multisig key %s."
+const MULTISIG_NONCE_MISMATCH = "Partial signature is for nonce %d, not %d."
+
+// GRANT
+const GRANT_NOT_FOUND = "%s has no grant from %s."
+const GRANT_EXPIRED = "Grant from %s to %s expired at %d."
+const GRANT_MSG_NOT_ALLOWED = "Grant from %s to %s does not allow %s."
+const GRANT_SPEND_LIMIT_EXCEEDED = "Spending %s exceeds the remaining limit %s of the grant from %s to %s."
+const GRANT_INVALID_MSG_TYPE = "Message type %s cannot be granted."
+const GRANT_INVALID_SPEND_LIMIT = "Invalid spend limit %s."
+const GRANT_INVALID_EXPIRATION = "Grant expiration %d is before current block time %d."
+const GRANT_SELF = "Account %s cannot grant itself."
+const GRANT_UNKNOWN_GRANTER = "Granter %s does not exist."
+
 // Proto Error
 const ACCOUNT_INVALID_STRUCT = "accountMapper requires a struct proto BaseAccount, or a pointer to one"
 const ACCOUNT_INVALID_INTERFACE = "accountMapper requries a proto BaseAccount, but %v doesn't implement BaseAccount interface."
@@ -59,3 +149,31 @@ const RES_OWN_ACCOUNT = "An account can only burn Coins of its own. Account %s !
 
 // BANK
 const BANK_INVALID_BURNT_DENOM = "Only booking denom %s is allowed to be burnt."
+
+// ISSUERS
+const ISSUER_NOT_FOUND = "%s is not a registered issuer."
+const ISSUER_DENOM_NOT_ALLOWED = "Issuer %s cannot mint %s."
+const ISSUER_CAP_EXCEEDED = "Minting %s exceeds the cap of issuer %s, %s minted out of %s."
+const ISSUER_WINDOW_EXCEEDED = "Minting %s exceeds the limit of issuer %s, %s minted out of %s in the last %d seconds."
+const ISSUER_INVALID_LIMIT = "Invalid mint limit %s."
+const ISSUER_DUPLICATE_DENOM = "Issuer has more than one mint limit for %s."
+const ISSUER_NO_LIMITS = "Issuer needs at least one mint limit."
+const ISSUER_TOO_MANY_LIMITS = "Issuer has %d mint limits, at most %d are allowed."
+
+// FEE SCHEDULE
+const FEE_INVALID_SCHEDULE = "Invalid fee %s."
+const FEE_INVALID_DENOM = "Fees are paid in %s, gas is priced in it, got %s."
+
+// SUPPLY
+const SUPPLY_MISMATCH = "Total supply %s does not match %s held by accounts."
+
+// REPUTATION
+const REVIEW_INVALID_RATING = "Rating %d must be between %d and %d."
+const REVIEW_INVALID_HASH = "Review content hash must be 1 to %d bytes long."
+const REVIEW_BOOKING_NOT_COMPLETED = "Booking %s is not completed and cannot be reviewed."
+const REVIEW_NOT_PARTY = "Only the renter or the asset owner of booking %s can review it, not %s."
+const REVIEW_ALREADY_EXISTS = "Booking %s was already reviewed by %s."
+
+// GAS
+const GAS_LIMIT_TOO_HIGH = "Gas limit %d is above the maximum of %d."
+const GAS_PRICE_TOO_LOW = "Gas price %d is below the minimum of %d."
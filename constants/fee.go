@@ -1,6 +1,8 @@
 package constants
 
-// Level of each message types
+// Level of each message types, by route and type name, e.g. booking/MsgBook.
+// Messages without entry in the fee schedule store are charged their level, messages without level nothing.
+// Asset messages are charged nothing.
 type FeeLevel int
 
 const (
@@ -11,12 +13,25 @@ const (
 )
 
 var LEVELS = map[string]FeeLevel{
-	"MsgSend":     LOW,
-	"MsgCreate":   HIGH,
-	"MsgUpdate":   MED,
-	"MsgDelete":   LOW,
-	"MsgBook":     HIGH,
-	"MsgComplete": MED,
+	MESSAGE_BANK + "/MsgSend":                  LOW,
+	MESSAGE_EXCHANGE_RATE + "/MsgCreate":       HIGH,
+	MESSAGE_EXCHANGE_RATE + "/MsgUpdate":       MED,
+	MESSAGE_EXCHANGE_RATE + "/MsgDelete":       LOW,
+	MESSAGE_BOOKING + "/MsgBook":               HIGH,
+	MESSAGE_BOOKING + "/MsgComplete":           MED,
+	MESSAGE_BOOKING + "/MsgCancelBooking":      MED,
+	MESSAGE_BOOKING + "/MsgConfirmBooking":     LOW,
+	MESSAGE_BOOKING + "/MsgRejectBooking":      LOW,
+	MESSAGE_BOOKING + "/MsgCheckIn":            LOW,
+	MESSAGE_BOOKING + "/MsgCheckOut":           LOW,
+	MESSAGE_BOOKING + "/MsgSubscribe":          HIGH,
+	MESSAGE_BOOKING + "/MsgCancelSubscription": MED,
+	MESSAGE_BOOKING + "/MsgClaimDeposit":       MED,
+	MESSAGE_DISPUTE + "/MsgOpenDispute":        MED,
+	MESSAGE_DISPUTE + "/MsgVoteDispute":        LOW,
+	MESSAGE_REPUTATION + "/MsgReview":          LOW,
+	MESSAGE_AUTH + "/MsgGrant":                 LOW,
+	MESSAGE_AUTH + "/MsgRevoke":                LOW,
 }
 
 var FEE_LEVELS = map[FeeLevel]int{
@@ -27,3 +42,13 @@ var FEE_LEVELS = map[FeeLevel]int{
 }
 
 const FEE_DENOM = "SHR"
+
+// GAS
+// Transactions pay the gas they use at their gas price, the fee of the message in the fee schedule is the minimum.
+const GAS_DEFAULT_LIMIT = uint64(200000) // gas limit of a transaction without one
+const GAS_MAX_LIMIT = uint64(10000000)   // most gas a transaction can ask for
+const GAS_PRICE_PRECISION = 9            // gas prices are in 10^-9 FEE_DENOM per gas
+const GAS_MIN_PRICE = int64(1000)        // lowest gas price, the price of a transaction without one
+const GAS_PER_TX_BYTE = uint64(10)       // gas per byte of a transaction
+const GAS_PER_SIGNATURE = uint64(1000)   // gas to verify a signature, for each key of a multisig
+const GAS_PER_FEE_CHARGE = uint64(5000)  // gas to charge a fee, metered before the fee is known
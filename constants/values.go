@@ -6,6 +6,7 @@ import "time"
 const DEFAULT_DENOM = "SHR"
 const DEFAULT_AMOUNT = 0
 const PREFIX_ADDRESS = "account:" // address to string to store in Auth Module
+const PREFIX_GRANT = "grant:"     // granter and grantee addresses to store a grant in Auth Module
 
 // STORE
 const STORE_BANK = "bank"
@@ -14,6 +15,9 @@ const STORE_ASSET = "asset"
 const STORE_AUTH = "auth"
 const STORE_POS = "pos"
 const STORE_EXCHANGE = "excrate"
+const STORE_DISPUTE = "dispute"
+const STORE_REPUTATION = "reputation"
+const STORE_FEE = "fee"
 
 // MESSAGE TYPE
 const MESSAGE_AUTH = "auth"
@@ -22,6 +26,9 @@ const MESSAGE_BANK = "bank"
 const MESSAGE_BOOKING = "booking"
 const MESSAGE_POS = "pos"
 const MESSAGE_EXCHANGE_RATE = "exchangerate"
+const MESSAGE_DISPUTE = "dispute"
+const MESSAGE_REPUTATION = "reputation"
+const MESSAGE_FEE = "fee"
 
 // ALLOWED DENOM
 var DENOM_LIST = map[string]bool{"SHRP": true, "SHR": true}
@@ -33,6 +40,140 @@ var DEFAULT_RESERVE = "405C725BC461DCA455B8AA84769E8ACE6B3763F4"
 var POS_BLOCK_REWARD = int64(5)
 var UNBONDING_TIME time.Duration = 60 * 60 * 24 * 3 * time.Second //3 weeks -> adjust it
 
+// REFUND POLICY
+const REFUND_FULL = "full"       // renter gets back the whole payment
+const REFUND_PARTIAL = "partial" // renter gets back a percentage when cancelling before the start
+const REFUND_NONE = "none"       // whole payment goes to the owner
+
+// ASSET METADATA
+const PRICING_HOUR = "hour"
+const PRICING_DAY = "day"
+
+// seconds in each pricing unit
+var PRICING_UNITS = map[string]int64{
+	PRICING_HOUR: 60 * 60,
+	PRICING_DAY:  60 * 60 * 24,
+}
+
+// assets without a pricing unit are billed per hour
+const PRICING_DEFAULT_UNIT = PRICING_HOUR
+
+// PRICING RULES
+const SECONDS_PER_DAY = 60 * 60 * 24
+const SECONDS_PER_WEEK = 7 * SECONDS_PER_DAY
+const PRICING_MAX_RULES = 16                  // tiers and surcharges of an asset, each
+const PRICING_MAX_SURCHARGE = 1000            // percentage
+const PRICING_MAX_SURCHARGED_UNITS = 24 * 366 // units priced one by one when an asset has surcharges
+
+const GEOHASH_ALPHABET = "0123456789bcdefghjkmnpqrstuvwxyz"
+const ASSET_MAX_GEOHASH_LENGTH = 12
+const ASSET_MAX_CATEGORY_LENGTH = 64
+const ASSET_MAX_URI_LENGTH = 256
+const ASSET_DEFAULT_PAGE_LIMIT = 20
+const ASSET_MAX_PAGE_LIMIT = 100
+
+// BOOKING ID
+const LEGACY_BOOKING_ID_LENGTH = 4         // hex characters of booking IDs before full length hashes
+const BOOKING_ID_MIGRATION_HEIGHT = int64(1) // first block legacy booking IDs can be migrated at. The migration runs once
+
+// BOOKING MODES
+const BOOKING_MODE_INSTANT = "instant" // bookings are confirmed as soon as the slot is free
+const BOOKING_MODE_REQUEST = "request" // bookings wait for the owner to accept them
+
+var BOOKING_REQUEST_TIMEOUT = int64(17280) // blocks an owner has to answer a booking request, about a day
+
+// BOOKING ATTESTATIONS
+var BOOKING_ATTESTATION_WINDOW = int64(10) // blocks a device attestation stays valid for
+
+// BOOKING STATUS
+const BOOKING_STATUS_REQUESTED = "requested"   // waiting for the asset owner to confirm
+const BOOKING_STATUS_CONFIRMED = "confirmed"   // slot reserved, renter can check in
+const BOOKING_STATUS_CHECKED_IN = "checked_in" // renter has the asset
+const BOOKING_STATUS_COMPLETED = "completed"
+const BOOKING_STATUS_CANCELLED = "cancelled"
+const BOOKING_STATUS_DISPUTED = "disputed"
+
+// SUBSCRIPTIONS
+const SUBSCRIPTION_STATUS_ACTIVE = "active"
+const SUBSCRIPTION_STATUS_CANCELLED = "cancelled"
+const SUBSCRIPTION_STATUS_LAPSED = "lapsed" // renter could not pay a period
+
+// seconds in each subscription period
+var SUBSCRIPTION_PERIODS = map[string]int64{
+	"day":   SECONDS_PER_DAY,
+	"week":  SECONDS_PER_WEEK,
+	"month": 30 * SECONDS_PER_DAY,
+}
+
+// BOOKING QUERY FILTERS
+// Any booking status is a valid filter as well
+const BOOKING_FILTER_ACTIVE = "active"
+const BOOKING_FILTER_COMPLETED = BOOKING_STATUS_COMPLETED
+
+// DEPOSIT
+var DEPOSIT_CLAIM_WINDOW = int64(60 * 60 * 24 * 3) // seconds after completion an owner can claim a deposit
+
+// ESCROW INVARIANT
+var ESCROW_INVARIANT_PERIOD = int64(100) // blocks between two checks of the booking escrow at EndBlock
+var ESCROW_INVARIANT_HALT = false        // halt the chain on a mismatch instead of logging it
+
+// DISPUTE
+var DISPUTE_VOTING_PERIOD = int64(60 * 60 * 24 * 7) // seconds arbitrators have to vote on a dispute
+var DISPUTE_DEFAULT_RENTER_SHARE = int64(50)        // percentage awarded to the renter when nobody voted
+var DISPUTE_INVARIANT_PERIOD = int64(100)           // blocks between two checks of the frozen funds at EndBlock
+var DISPUTE_INVARIANT_HALT = false                  // halt the chain on a mismatch instead of logging it
+
+// REPUTATION
+const REPUTATION_MIN_RATING = 1
+const REPUTATION_MAX_RATING = 5
+const REPUTATION_MAX_HASH_LENGTH = 64
+
+// ISSUERS
+const ISSUER_MAX_LIMITS = 16 // mint limits of an issuer, one per denom
+const SUPPLY_MINT = "mint"
+const SUPPLY_BURN = "burn"
+const SUPPLY_LEDGER_PAGE = 100 // supply entries returned by a single query
+
+// SUPPLY INVARIANT
+// Each check reads every account within one block, its cost grows with the number of accounts.
+var SUPPLY_INVARIANT_PERIOD = int64(10000) // blocks between two checks of the total supply at EndBlock, about 14 hours
+var SUPPLY_INVARIANT_HALT = false          // halt the chain on a mismatch instead of logging it
+
+// limits of the reserve accounts registered as issuers in a generated genesis
+var ISSUER_DEFAULT_CAP = int64(1000000000)
+var ISSUER_DEFAULT_WINDOW_LIMIT = int64(10000000)
+var ISSUER_DEFAULT_WINDOW = int64(SECONDS_PER_DAY)
+
+// TRANSACTIONS
+const AUTH_MAX_MSGS = 16 // messages in a single AuthTx
+
+// MULTISIG
+const MULTISIG_MAX_KEYS = 16
+
+// GRANTS
+const GRANT_MAX_MSG_TYPES = 16
+
+// messages a grantee can sign on behalf of a granter, by route and type name.
+// Each of them moves none of the granter coins or charges them to the grant, as fees are.
+var GRANTABLE_MSGS = map[string]bool{
+	"bank/MsgSend":                  true,
+	"asset/MsgCreate":               true,
+	"asset/MsgUpdate":               true,
+	"asset/MsgDelete":               true,
+	"booking/MsgBook":               true,
+	"booking/MsgComplete":           true,
+	"booking/MsgCancelBooking":      true,
+	"booking/MsgConfirmBooking":     true,
+	"booking/MsgRejectBooking":      true,
+	"booking/MsgCheckIn":            true,
+	"booking/MsgCheckOut":           true,
+	"booking/MsgClaimDeposit":       true,
+	"booking/MsgSubscribe":          true,
+	"booking/MsgCancelSubscription": true,
+	"dispute/MsgOpenDispute":        true,
+	"reputation/MsgReview":          true,
+}
+
 //POS Constant
 var MIN_MASTER_NODE_TOKEN int64 = 2000000
 
@@ -27,6 +27,9 @@ func NewBaseApp(
 
 func (app *BaseApp) AddRoute(path string, handler sdkTypes.Handler) bapp.Router{
 	// Wrap around every handler to ensure Fee is Called
+	// Fee is charged per message, a transaction with several messages pays the sum.
+	// Each message pays for the gas it used, metered by the gas meter the ante handler sets.
+	// They all run in the same cache, nothing is written, fees included, if one fails.
 	newHandler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 
 		// our handler
@@ -34,7 +37,7 @@ func (app *BaseApp) AddRoute(path string, handler sdkTypes.Handler) bapp.Router{
 
 		// perform Fee Handler
 		// Tendermit skip write cache if Result is not OK
-		sdkResult, _ := app.FeeHandler()(ctx, result)
+		sdkResult, _ := app.FeeHandler()(ctx, msg, result)
 		return sdkResult
 	}
 	return app.Router().AddRoute(path, newHandler)
@@ -17,7 +17,7 @@ func AddRoute(app *BaseApp, path string, h types.Handler) bapp.Router {
 
 		// perform Fee Handler
 		// Tendermit skip write cache if Result is not OK
-		sdkResult, _ := app.FeeHandler()(ctx, result)
+		sdkResult, _ := app.FeeHandler()(ctx, msg, result)
 		return sdkResult
 	}
 	return app.Router().AddRoute(path, newHandler)
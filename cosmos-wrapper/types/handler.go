@@ -4,4 +4,12 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 // Handler defines the core of the state transition function of an application.
-type Handler func(ctx sdk.Context, msg sdk.Msg) Result
\ No newline at end of file
+type Handler func(ctx sdk.Context, msg sdk.Msg) Result
+
+// WrapHandler - handler of a module whose results carry no fee.
+// Its messages still pay for the gas they use.
+func WrapHandler(h sdk.Handler) Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) Result {
+		return NewResult(h(ctx, msg))
+	}
+}
@@ -4,8 +4,11 @@ import (
 	// "encoding/hex"
     "encoding/json"
 	"fmt"
+	"strings"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	// "strconv"
+
+	"github.com/sharering/shareledger/constants"
 )
 
 // Asset asset infomation
@@ -13,8 +16,14 @@ type Asset struct {
 	UUID    string      `json:"uuid"`
 	Hash    []byte      `json:"hash"`
 	Creator sdk.AccAddress `json:"creator"`
-	Status  bool        `json:"status"`
+	Status  bool        `json:"status"` // accepts bookings. Cleared while a legacy booking holds the asset
 	Fee     int64       `json:"fee"`
+	Deposit int64       `json:"deposit"` // locked from the renter for the time of a booking
+	RefundPolicy RefundPolicy `json:"refund_policy"`
+	Metadata AssetMetadata `json:"metadata"`
+	BookingMode string `json:"booking_mode"` // BOOKING_MODE_INSTANT or BOOKING_MODE_REQUEST
+	PricingRules PricingRules `json:"pricing_rules"`
+	DevicePubKey PubKeySecp256k1 `json:"device_pubkey"` // attests check-in and check-out, zero if none
 }
 
 func (a Asset) String() string {
@@ -38,3 +47,166 @@ func NewAsset(uuid string, creator sdk.AccAddress, hash []byte, status bool, fee
 		Fee:     fee,
 	}
 }
+
+//--------------------------------------------------------
+
+// AssetMetadata - typed description of an asset for marketplaces
+type AssetMetadata struct {
+	Category    string `json:"category"`
+	Location    string `json:"location"`     // geohash
+	ContentURI  string `json:"content_uri"`  // off-chain description, pictures
+	Denom       string `json:"denom"`        // currency Fee and Deposit are priced in
+	PricingUnit string `json:"pricing_unit"` // PRICING_HOUR or PRICING_DAY, PRICING_DEFAULT_UNIT if empty
+}
+
+func NewAssetMetadata(category string, location string, contentURI string, denom string, pricingUnit string) AssetMetadata {
+	return AssetMetadata{
+		Category:    category,
+		Location:    location,
+		ContentURI:  contentURI,
+		Denom:       denom,
+		PricingUnit: pricingUnit,
+	}
+}
+
+// Validate - check metadata fields. Every field is optional for assets
+// created before metadata existed.
+func (m AssetMetadata) Validate() error {
+	if len(m.Category) > constants.ASSET_MAX_CATEGORY_LENGTH {
+		return fmt.Errorf(constants.ASSET_INVALID_METADATA, "category", m.Category)
+	}
+
+	if len(m.Location) > constants.ASSET_MAX_GEOHASH_LENGTH ||
+		strings.Trim(m.Location, constants.GEOHASH_ALPHABET) != "" {
+		return fmt.Errorf(constants.ASSET_INVALID_METADATA, "location", m.Location)
+	}
+
+	if len(m.ContentURI) > constants.ASSET_MAX_URI_LENGTH {
+		return fmt.Errorf(constants.ASSET_INVALID_METADATA, "content URI", m.ContentURI)
+	}
+
+	if m.Denom != "" && !IsValidDenom(m.Denom) {
+		return fmt.Errorf(constants.ASSET_INVALID_METADATA, "denom", m.Denom)
+	}
+
+	if _, ok := constants.PRICING_UNITS[m.PricingUnit]; m.PricingUnit != "" && !ok {
+		return fmt.Errorf(constants.ASSET_INVALID_METADATA, "pricing unit", m.PricingUnit)
+	}
+
+	return nil
+}
+
+// IsValidAssetUUID - UUIDs are printable so that they never collide with
+// the single byte prefixes of the asset store indexes
+func IsValidAssetUUID(uuid string) bool {
+	if len(uuid) == 0 {
+		return false
+	}
+	for _, c := range uuid {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// PriceDenom - currency of Fee and Deposit. Assets without one are priced in the booking denom
+func (a Asset) PriceDenom() string {
+	if a.Metadata.Denom == "" {
+		return constants.BOOKING_DENOM
+	}
+	return a.Metadata.Denom
+}
+
+// SameBookingTerms - whether *other* prices and books as this asset does
+func (a Asset) SameBookingTerms(other Asset) bool {
+	return a.Fee == other.Fee &&
+		a.Deposit == other.Deposit &&
+		a.PriceDenom() == other.PriceDenom() &&
+		a.RefundPolicy == other.RefundPolicy &&
+		a.BookingMode == other.BookingMode &&
+		a.PricingUnit() == other.PricingUnit() &&
+		a.PricingRules.Equal(other.PricingRules) &&
+		a.DevicePubKey == other.DevicePubKey
+}
+
+// IsValidBookingMode - an empty mode books instantly, as assets did before modes existed
+func IsValidBookingMode(mode string) bool {
+	switch mode {
+	case "", constants.BOOKING_MODE_INSTANT, constants.BOOKING_MODE_REQUEST:
+		return true
+	}
+	return false
+}
+
+// RequiresApproval - bookings of this asset wait for the owner to accept them
+func (a Asset) RequiresApproval() bool {
+	return a.BookingMode == constants.BOOKING_MODE_REQUEST
+}
+
+// HasDevice - whether a device attests check-in and check-out of this asset
+func (a Asset) HasDevice() bool {
+	return !a.DevicePubKey.IsZero()
+}
+
+// PricingUnit - unit the asset is priced per. Assets without one are priced per PRICING_DEFAULT_UNIT
+func (a Asset) PricingUnit() string {
+	if _, ok := constants.PRICING_UNITS[a.Metadata.PricingUnit]; !ok {
+		return constants.PRICING_DEFAULT_UNIT
+	}
+	return a.Metadata.PricingUnit
+}
+
+// BillingUnits - number of pricing units covering the period [start, end)
+func (a Asset) BillingUnits(start int64, end int64) int64 {
+	unit := constants.PRICING_UNITS[a.PricingUnit()]
+	return (end - start + unit - 1) / unit
+}
+
+//--------------------------------------------------------
+
+// RefundPolicy - share of a booking payment returned to the renter when the booking is cancelled
+type RefundPolicy struct {
+	Kind    string `json:"kind"`    // REFUND_FULL, REFUND_PARTIAL or REFUND_NONE
+	Percent int64  `json:"percent"` // refunded percentage when a partial refund applies
+}
+
+func NewRefundPolicy(kind string, percent int64) RefundPolicy {
+	return RefundPolicy{
+		Kind:    kind,
+		Percent: percent,
+	}
+}
+
+// IsValid - whether the policy is known. An empty policy is a full refund,
+// which is what assets created before refund policies existed offer.
+func (p RefundPolicy) IsValid() bool {
+	switch p.Kind {
+	case "", constants.REFUND_FULL, constants.REFUND_NONE:
+		return true
+	case constants.REFUND_PARTIAL:
+		return p.Percent >= 0 && p.Percent <= 100
+	}
+	return false
+}
+
+// AllowsLateCancel - whether a renter can still cancel a booking once it started.
+// Only a partial refund policy says what happens then: nothing is refunded.
+func (p RefundPolicy) AllowsLateCancel() bool {
+	return p.Kind == constants.REFUND_PARTIAL
+}
+
+// Refund - part of *payment* returned to the renter when a booking starting at *start*
+// is cancelled at *now*. Partial refunds are only granted before the booking starts.
+func (p RefundPolicy) Refund(payment Coin, start int64, now int64) Coin {
+	switch p.Kind {
+	case constants.REFUND_NONE:
+		return NewCoin(payment.Denom, 0)
+	case constants.REFUND_PARTIAL:
+		if now >= start {
+			return NewCoin(payment.Denom, 0)
+		}
+		return payment.Mul(NewDecWithPrec(p.Percent, 2))
+	}
+	return payment
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"encoding/json"
+
+	"github.com/sharering/shareledger/constants"
 )
 
 // Simple Booking struct
@@ -12,19 +14,81 @@ type Booking struct {
 	Renter      sdk.AccAddress `json:"renter"`
 	UUID        string      `json:"uuid"`
 	Duration    int64       `json:"duration"`
-	IsCompleted bool        `json:"is_completed"`
+	Start       int64       `json:"start"` // unix time the booked slot begins
+	End         int64       `json:"end"`   // unix time the booked slot ends
+	Payment     Coin        `json:"payment"` // held in escrow until the booking is completed
+	Deposit     Coin        `json:"deposit"` // held in escrow until the claim window closes
+	Paid        Coin        `json:"paid"`    // payment and deposit as paid by the renter, possibly in another denom
+	DepositClaimed Coin     `json:"deposit_claimed"` // part of the deposit paid to the owner
+	RefundPolicy RefundPolicy `json:"refund_policy"` // policy of the asset when it was booked
+	ClaimDeadline int64     `json:"claim_deadline"`  // unix time the claim window closes
+	RequestDeadline int64   `json:"request_deadline"` // last block the owner can answer a booking request at
+	CheckInHeight  int64    `json:"check_in_height"`  // block the asset device attested the check-in at
+	CheckOutHeight int64    `json:"check_out_height"` // block the asset device attested the check-out at
+	Status      string      `json:"status"`          // one of BOOKING_STATUS_*
+	History     []BookingStatusChange `json:"history"`
+	IsCompleted bool        `json:"is_completed"` // kept in sync with Status for older clients
+}
+
+// BookingStatusChange - a status a booking entered and the block it entered it at
+type BookingStatusChange struct {
+	Status string `json:"status"`
+	Height int64  `json:"height"`
 }
 
-func NewBooking(_bid string, _acc sdk.AccAddress, _uuid string, _dur int64, _isCompleted bool) Booking {
+func NewBooking(_bid string, _acc sdk.AccAddress, _uuid string, _dur int64, _start int64, _end int64, _isCompleted bool) Booking {
 	return Booking{
 		BookingID: _bid,
 		Renter:    _acc,
 		UUID:      _uuid,
 		Duration:  _dur,
+		Start:     _start,
+		End:       _end,
 		IsCompleted: _isCompleted,
 	}
 }
 
+// Overlaps - whether this booking shares any time with the slot [start, end)
+func (b Booking) Overlaps(start int64, end int64) bool {
+	return b.Start < end && start < b.End
+}
+
+// IsLegacy - booking made before bookings had a time window and an escrow. It has no slot in the
+// calendar of its asset and holds the asset by clearing the asset status instead. Its payment was
+// taken from the renter at booking and is not held in escrow.
+func (b Booking) IsLegacy() bool {
+	return b.End == 0 || b.Payment.Denom == ""
+}
+
+// GetStatus - current status of the booking.
+// Bookings stored before statuses existed only carry IsCompleted.
+func (b Booking) GetStatus() string {
+	if b.Status != "" {
+		return b.Status
+	}
+
+	if b.IsCompleted {
+		return constants.BOOKING_STATUS_COMPLETED
+	}
+	return constants.BOOKING_STATUS_CONFIRMED
+}
+
+// IsActive - booking still holds its slot in the asset calendar
+func (b Booking) IsActive() bool {
+	switch b.GetStatus() {
+	case constants.BOOKING_STATUS_REQUESTED,
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_CHECKED_IN:
+		return true
+	}
+	return false
+}
+
+// RemainingDeposit - part of the deposit still held in escrow
+func (b Booking) RemainingDeposit() Coin {
+	return b.Deposit.Minus(b.DepositClaimed)
+}
+
 func (b Booking) String() string {
 	//return fmt.Sprintf("{BookingID: %s, Renter: %s, UUID: %x, Duration: %d, IsCompleted: %t}",
 	//	b.BookingID, b.Renter, b.UUID, b.Duration, b.IsCompleted)
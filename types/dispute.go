@@ -0,0 +1,82 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Dispute - disagreement between renter and owner about a booking.
+// Funds held for the booking are frozen until arbitrators decide how to split them.
+type Dispute struct {
+	BookingID   string         `json:"bookingId"`
+	Claimant    sdk.AccAddress `json:"claimant"`
+	Renter      sdk.AccAddress `json:"renter"`
+	Owner       sdk.AccAddress `json:"owner"`
+	Evidence    []byte         `json:"evidence"`
+	Frozen      Coin           `json:"frozen"`
+	Votes       []DisputeVote  `json:"votes"`
+	Deadline    int64          `json:"deadline"` // unix time voting closes
+	IsResolved  bool           `json:"is_resolved"`
+	RenterShare int64          `json:"renter_share"` // percentage of frozen funds awarded to the renter
+}
+
+// DisputeVote - split of the frozen funds proposed by an arbitrator
+type DisputeVote struct {
+	Arbitrator  sdk.AccAddress `json:"arbitrator"`
+	RenterShare int64          `json:"renter_share"`
+}
+
+func NewDispute(bookingID string, claimant sdk.AccAddress, renter sdk.AccAddress, owner sdk.AccAddress,
+	evidence []byte, frozen Coin, deadline int64) Dispute {
+	return Dispute{
+		BookingID: bookingID,
+		Claimant:  claimant,
+		Renter:    renter,
+		Owner:     owner,
+		Evidence:  evidence,
+		Frozen:    frozen,
+		Votes:     []DisputeVote{},
+		Deadline:  deadline,
+	}
+}
+
+// HasVoted - whether an arbitrator has already voted
+func (d Dispute) HasVoted(arbitrator sdk.AccAddress) bool {
+	for _, vote := range d.Votes {
+		if bytes.Equal(vote.Arbitrator, arbitrator) {
+			return true
+		}
+	}
+	return false
+}
+
+// AverageShare - renter share averaged over the votes cast, or *fallback* without votes
+func (d Dispute) AverageShare(fallback int64) int64 {
+	if len(d.Votes) == 0 {
+		return fallback
+	}
+
+	var sum int64
+	for _, vote := range d.Votes {
+		sum += vote.RenterShare
+	}
+	return sum / int64(len(d.Votes))
+}
+
+// Split - frozen funds awarded to renter and owner for a renter share in percent
+func (d Dispute) Split(renterShare int64) (renterPart Coin, ownerPart Coin) {
+	renterPart = d.Frozen.Mul(NewDecWithPrec(renterShare, 2))
+	ownerPart = d.Frozen.Minus(renterPart)
+	return
+}
+
+func (d Dispute) String() string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%s", b)
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// MintLimit - how much of a denom an issuer can mint, in total and within a rolling window
+type MintLimit struct {
+	Cap         Coin  `json:"cap"`          // total the issuer can ever mint
+	WindowLimit Coin  `json:"window_limit"` // most minted within any Window
+	Window      int64 `json:"window"`       // seconds
+}
+
+func NewMintLimit(cap Coin, windowLimit Coin, window int64) MintLimit {
+	return MintLimit{
+		Cap:         cap,
+		WindowLimit: windowLimit,
+		Window:      window,
+	}
+}
+
+// Validate - check amounts are positive and of the same denom
+func (l MintLimit) Validate() error {
+	if !l.Cap.HasValidDenom() || l.Cap.IsNil() || !l.Cap.IsPositive() ||
+		l.WindowLimit.Denom != l.Cap.Denom || l.WindowLimit.IsNil() || !l.WindowLimit.IsPositive() ||
+		l.WindowLimit.GT(l.Cap) || l.Window <= 0 {
+		return fmt.Errorf(constants.ISSUER_INVALID_LIMIT, l.String())
+	}
+	return nil
+}
+
+func (l MintLimit) String() string {
+	return fmt.Sprintf("%s, %s per %ds", l.Cap.String(), l.WindowLimit.String(), l.Window)
+}
+
+// Issuer - account allowed to mint coins with MsgLoad
+type Issuer struct {
+	Address sdk.AccAddress `json:"address"`
+	Limits  []MintLimit    `json:"limits"` // one per denom the issuer mints
+	Minted  Coins          `json:"minted"` // total minted so far, counted against caps
+}
+
+func NewIssuer(address sdk.AccAddress, limits []MintLimit) Issuer {
+	return Issuer{
+		Address: address,
+		Limits:  limits,
+		Minted:  NewDefaultCoins(),
+	}
+}
+
+// Validate - check every limit, at most one per denom
+func (i Issuer) Validate() error {
+	if len(i.Limits) > constants.ISSUER_MAX_LIMITS {
+		return fmt.Errorf(constants.ISSUER_TOO_MANY_LIMITS, len(i.Limits), constants.ISSUER_MAX_LIMITS)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, l := range i.Limits {
+		if err := l.Validate(); err != nil {
+			return err
+		}
+
+		if seen[l.Cap.Denom] {
+			return fmt.Errorf(constants.ISSUER_DUPLICATE_DENOM, l.Cap.Denom)
+		}
+		seen[l.Cap.Denom] = true
+	}
+	return nil
+}
+
+// LimitOf - mint limit of *denom*, false if the issuer cannot mint it
+func (i Issuer) LimitOf(denom string) (MintLimit, bool) {
+	for _, l := range i.Limits {
+		if l.Cap.Denom == denom {
+			return l, true
+		}
+	}
+	return MintLimit{}, false
+}
+
+func (i Issuer) String() string {
+	b, _ := json.Marshal(i)
+	return fmt.Sprintf("%s", b)
+}
+
+// SupplyEntry - record of a mint or a burn in the supply ledger
+type SupplyEntry struct {
+	Seq     int64          `json:"seq"`
+	Kind    string         `json:"kind"` // SUPPLY_MINT or SUPPLY_BURN
+	Signer  sdk.AccAddress `json:"signer"`
+	Account sdk.AccAddress `json:"account"` // account credited or debited
+	Amount  Coin           `json:"amount"`
+	Height  int64          `json:"height"`
+	Time    int64          `json:"time"`
+}
+
+func (e SupplyEntry) String() string {
+	b, _ := json.Marshal(e)
+	return fmt.Sprintf("%s", b)
+}
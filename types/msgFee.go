@@ -0,0 +1,41 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgFee - fee charged for a message type of a route
+type MsgFee struct {
+	Route   string `json:"route"`    // e.g. booking, exchangerate
+	MsgType string `json:"msg_type"` // Go type name, e.g. MsgBook
+	Amount  int64  `json:"amount"`
+	Denom   string `json:"denom"`
+}
+
+func NewMsgFee(route string, msgType string, amount int64, denom string) MsgFee {
+	return MsgFee{
+		Route:   route,
+		MsgType: msgType,
+		Amount:  amount,
+		Denom:   denom,
+	}
+}
+
+func (f MsgFee) Validate() error {
+	if f.Route == "" || f.MsgType == "" || f.Amount < 0 || !IsValidDenom(f.Denom) {
+		return fmt.Errorf(constants.FEE_INVALID_SCHEDULE, f.String())
+	}
+	// the fee of a message is the minimum of what its gas costs, both in the same denom
+	if f.Denom != constants.FEE_DENOM {
+		return fmt.Errorf(constants.FEE_INVALID_DENOM, constants.FEE_DENOM, f.Denom)
+	}
+	return nil
+}
+
+func (f MsgFee) String() string {
+	b, _ := json.Marshal(f)
+	return fmt.Sprintf("%s", b)
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sha3 "golang.org/x/crypto/sha3"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+//----------------------------------------
+
+var _ PubKey = PubKeyMultisig{}
+
+// PubKeyMultisig - key of an account controlled by several keys,
+// any Threshold of which have to sign
+type PubKeyMultisig struct {
+	Threshold int64             `json:"threshold"`
+	PubKeys   []PubKeySecp256k1 `json:"pub_keys"`
+}
+
+func NewPubKeyMultisig(threshold int64, pubKeys []PubKeySecp256k1) PubKeyMultisig {
+	return PubKeyMultisig{
+		Threshold: threshold,
+		PubKeys:   pubKeys,
+	}
+}
+
+// Validate - check the threshold can be reached and every key is usable once
+func (pubKey PubKeyMultisig) Validate() error {
+	n := int64(len(pubKey.PubKeys))
+
+	if n > constants.MULTISIG_MAX_KEYS {
+		return fmt.Errorf(constants.MULTISIG_TOO_MANY_KEYS, n, constants.MULTISIG_MAX_KEYS)
+	}
+
+	if pubKey.Threshold < 1 || pubKey.Threshold > n {
+		return fmt.Errorf(constants.MULTISIG_INVALID_THRESHOLD, pubKey.Threshold, n)
+	}
+
+	for i, key := range pubKey.PubKeys {
+		if !key.IsValid() {
+			return fmt.Errorf(constants.MULTISIG_INVALID_KEY, key.String())
+		}
+
+		if pubKey.IndexOf(key) != i {
+			return fmt.Errorf(constants.MULTISIG_DUPLICATE_KEY, key.String())
+		}
+	}
+	return nil
+}
+
+// IndexOf - position of *key* in the key set, -1 if it is not part of it
+func (pubKey PubKeyMultisig) IndexOf(key PubKey) int {
+	for i, k := range pubKey.PubKeys {
+		if k.Equals(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Address - last 20 bytes of Keccak256 over the threshold and the keys, in order,
+// so the same keys with another threshold make another account
+func (pubKey PubKeyMultisig) Address() sdk.AccAddress {
+	hasher := sha3.NewLegacyKeccak256()
+
+	threshold := make([]byte, 8)
+	binary.BigEndian.PutUint64(threshold, uint64(pubKey.Threshold))
+	hasher.Write(threshold)
+
+	for _, key := range pubKey.PubKeys {
+		hasher.Write(key[:])
+	}
+
+	var sha []byte
+	sha = hasher.Sum(sha)
+	return sdk.AccAddress(sha[12:])
+}
+
+func (pubKey PubKeyMultisig) Bytes() []byte {
+	bz, err := json.Marshal(pubKey)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// VerifyBytes - whether at least Threshold keys signed *msg*
+func (pubKey PubKeyMultisig) VerifyBytes(msg []byte, sig_ Signature) bool {
+	sig, ok := sig_.(SignatureMultisig)
+	if !ok {
+		return false
+	}
+
+	if pubKey.Validate() != nil || len(sig) != len(pubKey.PubKeys) {
+		return false
+	}
+
+	var signed int64
+
+	for i, key := range pubKey.PubKeys {
+		if sig[i].IsZero() {
+			continue
+		}
+
+		if !key.VerifyBytes(msg, sig[i]) {
+			return false
+		}
+		signed++
+	}
+	return signed >= pubKey.Threshold
+}
+
+func (pubKey PubKeyMultisig) String() string {
+	return fmt.Sprintf("PubKeyMultisig{%d of %v}", pubKey.Threshold, pubKey.PubKeys)
+}
+
+func (pubKey PubKeyMultisig) Equals(other PubKey) bool {
+	otherMulti, ok := other.(PubKeyMultisig)
+	if !ok || pubKey.Threshold != otherMulti.Threshold || len(pubKey.PubKeys) != len(otherMulti.PubKeys) {
+		return false
+	}
+
+	for i, key := range pubKey.PubKeys {
+		if !key.Equals(otherMulti.PubKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+//----------------------------------------
+
+var _ Signature = SignatureMultisig{}
+
+// SignatureMultisig - signatures of the keys of a PubKeyMultisig, in the order of the keys.
+// Keys which did not sign have an empty signature.
+type SignatureMultisig []SignatureSecp256k1
+
+func NewSignatureMultisig(keys int) SignatureMultisig {
+	return make(SignatureMultisig, keys)
+}
+
+func (sig SignatureMultisig) Bytes() []byte {
+	bz, err := json.Marshal(sig)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (sig SignatureMultisig) IsZero() bool {
+	for _, s := range sig {
+		if !s.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func (sig SignatureMultisig) String() string {
+	return fmt.Sprintf("SignatureMultisig%v", []SignatureSecp256k1(sig))
+}
+
+func (sig SignatureMultisig) Equals(other Signature) bool {
+	otherMulti, ok := other.(SignatureMultisig)
+	if !ok || len(sig) != len(otherMulti) {
+		return false
+	}
+
+	for i, s := range sig {
+		if !bytes.Equal(s, otherMulti[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// PricingRules - adjustments of the flat Fee * Duration price of an asset.
+// Durations are counted in the units the booking Duration is counted in.
+type PricingRules struct {
+	MinDuration int64          `json:"min_duration"` // 0 for no minimum
+	MaxDuration int64          `json:"max_duration"` // 0 for no maximum
+	Tiers       []DurationTier `json:"tiers"`        // discounts for long bookings
+	Surcharges  []Surcharge    `json:"surcharges"`   // extra charges for times of the week
+}
+
+// DurationTier - discount granted to bookings lasting at least MinDuration
+type DurationTier struct {
+	MinDuration int64 `json:"min_duration"`
+	Discount    int64 `json:"discount"` // percentage off the price
+}
+
+// Surcharge - extra charge for units starting in [From, To).
+// From and To are seconds since Monday 00:00 UTC.
+type Surcharge struct {
+	From    int64 `json:"from"`
+	To      int64 `json:"to"`
+	Percent int64 `json:"percent"` // percentage added to the unit price
+}
+
+func NewPricingRules(minDuration int64, maxDuration int64, tiers []DurationTier, surcharges []Surcharge) PricingRules {
+	return PricingRules{
+		MinDuration: minDuration,
+		MaxDuration: maxDuration,
+		Tiers:       tiers,
+		Surcharges:  surcharges,
+	}
+}
+
+// Equal - whether both rules price bookings the same. No rules and empty rules are equal.
+func (r PricingRules) Equal(other PricingRules) bool {
+	if r.MinDuration != other.MinDuration || r.MaxDuration != other.MaxDuration ||
+		len(r.Tiers) != len(other.Tiers) || len(r.Surcharges) != len(other.Surcharges) {
+		return false
+	}
+
+	for i := range r.Tiers {
+		if r.Tiers[i] != other.Tiers[i] {
+			return false
+		}
+	}
+
+	for i := range r.Surcharges {
+		if r.Surcharges[i] != other.Surcharges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate - check rules are consistent. Empty rules keep the flat price.
+func (r PricingRules) Validate() error {
+	if r.MinDuration < 0 || r.MaxDuration < 0 ||
+		(r.MaxDuration > 0 && r.MaxDuration < r.MinDuration) {
+		return fmt.Errorf(constants.ASSET_INVALID_PRICING, "duration bounds",
+			fmt.Sprintf("[%d, %d]", r.MinDuration, r.MaxDuration))
+	}
+
+	if len(r.Tiers) > constants.PRICING_MAX_RULES || len(r.Surcharges) > constants.PRICING_MAX_RULES {
+		return fmt.Errorf(constants.ASSET_INVALID_PRICING, "rule count",
+			fmt.Sprintf("%d tiers, %d surcharges", len(r.Tiers), len(r.Surcharges)))
+	}
+
+	for _, tier := range r.Tiers {
+		if tier.MinDuration <= 0 || tier.Discount < 0 || tier.Discount > 100 {
+			return fmt.Errorf(constants.ASSET_INVALID_PRICING, "tier", fmt.Sprintf("%+v", tier))
+		}
+	}
+
+	for _, s := range r.Surcharges {
+		if s.From < 0 || s.To <= s.From || s.To > constants.SECONDS_PER_WEEK ||
+			s.Percent < 0 || s.Percent > constants.PRICING_MAX_SURCHARGE {
+			return fmt.Errorf(constants.ASSET_INVALID_PRICING, "surcharge", fmt.Sprintf("%+v", s))
+		}
+	}
+
+	return nil
+}
+
+// AllowsDuration - whether a booking lasting *duration* units can be made
+func (r PricingRules) AllowsDuration(duration int64) bool {
+	if duration < r.MinDuration {
+		return false
+	}
+	return r.MaxDuration == 0 || duration <= r.MaxDuration
+}
+
+// DiscountFor - discount percentage of the longest tier *duration* reaches
+func (r PricingRules) DiscountFor(duration int64) int64 {
+	var discount, reached int64
+
+	for _, tier := range r.Tiers {
+		if duration >= tier.MinDuration && tier.MinDuration > reached {
+			reached = tier.MinDuration
+			discount = tier.Discount
+		}
+	}
+	return discount
+}
+
+// SurchargeAt - highest surcharge percentage applying to a unit starting at unix time *t*.
+// Overlapping windows do not add up.
+func (r PricingRules) SurchargeAt(t int64) int64 {
+	// the unix epoch is a Thursday, 3 days after Monday
+	sinceMonday := (t + 3*constants.SECONDS_PER_DAY) % constants.SECONDS_PER_WEEK
+	if sinceMonday < 0 {
+		sinceMonday += constants.SECONDS_PER_WEEK
+	}
+
+	var surcharge int64
+
+	for _, s := range r.Surcharges {
+		if sinceMonday >= s.From && sinceMonday < s.To && s.Percent > surcharge {
+			surcharge = s.Percent
+		}
+	}
+	return surcharge
+}
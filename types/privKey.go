@@ -59,8 +59,11 @@ func (privKey PrivKeySecp256k1) Sign(msg sdk.Msg) Signature {
 }
 
 func (privKey PrivKeySecp256k1) SignWithNonce(msg sdk.Msg, nonce int64) Signature {
-	signBytes := msg.GetSignBytes()
+	return privKey.SignBytesWithNonce(msg.GetSignBytes(), nonce)
+}
 
+// SignBytesWithNonce - sign *signBytes* prefixed with *nonce*, as AuthSig verifies them
+func (privKey PrivKeySecp256k1) SignBytesWithNonce(signBytes []byte, nonce int64) Signature {
 	signBytesWithNonce := append([]byte(fmt.Sprintf("%d", nonce)), signBytes...)
 
 	msgHash := crypto.Sha256(signBytesWithNonce)
@@ -114,6 +114,17 @@ func (pubKey PubKeySecp256k1) Equals(other PubKey) bool {
 	}
 }
 
+// IsZero - whether no key is set
+func (pubKey PubKeySecp256k1) IsZero() bool {
+	return pubKey == PubKeySecp256k1{}
+}
+
+// IsValid - whether the key is a point of the secp256k1 curve
+func (pubKey PubKeySecp256k1) IsValid() bool {
+	_, err := btcec.ParsePubKey(pubKey[:], btcec.S256())
+	return err == nil
+}
+
 func (pubKey PubKeySecp256k1) ToABCIPubKey() secp256k1.PubKeySecp256k1 {
 	var pk secp256k1.PubKeySecp256k1
 
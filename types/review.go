@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Review - rating a party of a completed booking gives to the other party.
+// The review itself lives off-chain, only its hash is recorded.
+type Review struct {
+	BookingID   string         `json:"bookingId"`
+	UUID        string         `json:"uuid"`
+	Reviewer    sdk.AccAddress `json:"reviewer"`
+	Subject     sdk.AccAddress `json:"subject"` // account being rated
+	Rating      int64          `json:"rating"`
+	ContentHash []byte         `json:"content_hash"`
+	Height      int64          `json:"height"` // block the review was posted at
+}
+
+func NewReview(bookingID string, uuid string, reviewer sdk.AccAddress, subject sdk.AccAddress,
+	rating int64, contentHash []byte, height int64) Review {
+	return Review{
+		BookingID:   bookingID,
+		UUID:        uuid,
+		Reviewer:    reviewer,
+		Subject:     subject,
+		Rating:      rating,
+		ContentHash: contentHash,
+		Height:      height,
+	}
+}
+
+func (r Review) String() string {
+	b, _ := json.Marshal(r)
+	return fmt.Sprintf("%s", b)
+}
+
+// Score - aggregate of the ratings received by an account or an asset
+type Score struct {
+	Count int64 `json:"count"`
+	Sum   int64 `json:"sum"`
+}
+
+// Add - score once *rating* is counted
+func (s Score) Add(rating int64) Score {
+	s.Count++
+	s.Sum += rating
+	return s
+}
+
+// Average - mean rating, zero without ratings
+func (s Score) Average() Dec {
+	if s.Count == 0 {
+		return ZeroDec()
+	}
+	return NewDec(s.Sum).Quo(NewDec(s.Count))
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Subscription - recurring rental of an asset, charged to the renter every period
+// until it is cancelled or the renter cannot pay
+type Subscription struct {
+	SubscriptionID string         `json:"subscriptionId"`
+	Renter         sdk.AccAddress `json:"renter"`
+	UUID           string         `json:"uuid"`
+	Period         string         `json:"period"`      // one of SUBSCRIPTION_PERIODS
+	Amount         Coin           `json:"amount"`      // charged every period
+	Start          int64          `json:"start"`       // unix time of the first period
+	NextCharge     int64          `json:"next_charge"` // unix time the next period is charged
+	Charged        int64          `json:"charged"`     // number of periods paid
+	Status         string         `json:"status"`      // one of SUBSCRIPTION_STATUS_*
+	Held           Coin           `json:"held"`        // current period, in escrow until it has elapsed
+
+	// account which subscribed on behalf of the renter, its grant pays every period
+	Grantee sdk.AccAddress `json:"grantee,omitempty"`
+}
+
+func NewSubscription(id string, renter sdk.AccAddress, uuid string, period string,
+	amount Coin, start int64, status string) Subscription {
+	return Subscription{
+		SubscriptionID: id,
+		Renter:         renter,
+		UUID:           uuid,
+		Period:         period,
+		Amount:         amount,
+		Start:          start,
+		NextCharge:     start,
+		Status:         status,
+	}
+}
+
+// HasHeldPeriod - whether the current period is held in escrow.
+// Subscriptions charged before the escrow paid their owner at once.
+func (s Subscription) HasHeldPeriod() bool {
+	return s.Held.Denom != "" && s.Held.IsPositive()
+}
+
+func (s Subscription) String() string {
+	b, _ := json.Marshal(s)
+	return fmt.Sprintf("%s", b)
+}
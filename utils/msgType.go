@@ -5,8 +5,6 @@ import (
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-
-	"github.com/sharering/shareledger/constants"
 )
 
 // GetMsgType return type of message in string
@@ -20,15 +18,8 @@ func GetMsgType(msg sdk.Msg) string {
 	return msgType
 }
 
-func GetMsgFee(msg sdk.Msg) (int64, string) {
-
-	msgType := GetMsgType(msg)
-
-	msgLevel := constants.LEVELS[msgType]
-
-	if  msgLevel == constants.NONE {
-		return int64(0), ""
-	}
-
-	return int64(constants.FEE_LEVELS[msgLevel]), constants.FEE_DENOM
+// GetMsgKey returns route and type name of a message, e.g. booking/MsgBook.
+// Type() of most messages is their route, type names tell apart messages of a route.
+func GetMsgKey(msg sdk.Msg) string {
+	return msg.Route() + "/" + GetMsgType(msg)
 }
@@ -11,5 +11,6 @@ func RegisterCodec(cdc *amino.Codec) *amino.Codec {
 	cdc.RegisterConcrete(messages.MsgRetrieve{}, "shareledger/asset/MsgRetrieve", nil)
 	cdc.RegisterConcrete(messages.MsgUpdate{}, "shareledger/asset/MsgUpdate", nil)
 	cdc.RegisterConcrete(messages.MsgDelete{}, "shareledger/asset/MsgDelete", nil)
+	cdc.RegisterConcrete(messages.MsgTransferAsset{}, "shareledger/asset/MsgTransferAsset", nil)
 	return cdc
 }
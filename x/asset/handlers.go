@@ -28,6 +28,8 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleAssetUpdate(ctx, k, msg)
 		case messages.MsgDelete:
 			return handleAssetDelete(ctx, k, msg)
+		case messages.MsgTransferAsset:
+			return handleAssetTransfer(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
@@ -99,3 +101,16 @@ func handleAssetDelete(ctx sdk.Context, k Keeper, msg messages.MsgDelete) sdk.Re
 		// FeeDenom:  denom,
 	}
 }
+
+func handleAssetTransfer(ctx sdk.Context, k Keeper, msg messages.MsgTransferAsset) sdk.Result {
+
+	asset, err := k.TransferAsset(ctx, msg)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("%s", asset),
+		Tags: msg.Tags(),
+	}
+}
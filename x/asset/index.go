@@ -0,0 +1,100 @@
+package asset
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+)
+
+// GetAsset returns the asset stored under uuid
+func (k Keeper) GetAsset(ctx sdk.Context, uuid string) (types.Asset, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	assetBytes := store.Get([]byte(uuid))
+	if assetBytes == nil {
+		return types.Asset{}, false
+	}
+
+	var asset types.Asset
+
+	if err := json.Unmarshal(assetBytes, &asset); err != nil {
+		panic(err)
+	}
+	return asset, true
+}
+
+// GetAssets returns a page of all assets ordered by UUID. Pages start at 1
+func (k Keeper) GetAssets(ctx sdk.Context, page int, limit int) []types.Asset {
+	store := ctx.KVStore(k.storeKey)
+
+	// UUIDs are printable, every key from AssetKeyStart on is an asset
+	iterator := store.Iterator(AssetKeyStart, nil)
+	defer iterator.Close()
+
+	assets := []types.Asset{}
+	skip := (page - 1) * limit
+
+	for ; iterator.Valid() && len(assets) < limit; iterator.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		var asset types.Asset
+
+		if err := json.Unmarshal(iterator.Value(), &asset); err != nil {
+			panic(err)
+		}
+		assets = append(assets, asset)
+	}
+	return assets
+}
+
+// GetAssetsByCreator returns a page of the assets of a creator
+func (k Keeper) GetAssetsByCreator(ctx sdk.Context, creator sdk.AccAddress, page int, limit int) []types.Asset {
+	return k.getAssetsByPrefix(ctx, GetAssetsByCreatorPrefix(creator), page, limit)
+}
+
+// GetAssetsByCategory returns a page of the assets of a category
+func (k Keeper) GetAssetsByCategory(ctx sdk.Context, category string, page int, limit int) []types.Asset {
+	return k.getAssetsByPrefix(ctx, GetAssetsByCategoryPrefix(category), page, limit)
+}
+
+// getAssetsByPrefix returns a page of assets referenced by the index under prefix. Pages start at 1
+func (k Keeper) getAssetsByPrefix(ctx sdk.Context, prefix []byte, page int, limit int) []types.Asset {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	assets := []types.Asset{}
+	skip := (page - 1) * limit
+
+	for ; iterator.Valid() && len(assets) < limit; iterator.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		asset, found := k.GetAsset(ctx, string(iterator.Value()))
+		if found {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+// setIndexes references an asset from its creator and category
+func (k Keeper) setIndexes(ctx sdk.Context, asset types.Asset) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetAssetByCreatorKey(asset.Creator, asset.UUID), []byte(asset.UUID))
+	store.Set(GetAssetByCategoryKey(asset.Metadata.Category, asset.UUID), []byte(asset.UUID))
+}
+
+// removeIndexes drops the references of an asset from its creator and category
+func (k Keeper) removeIndexes(ctx sdk.Context, asset types.Asset) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetAssetByCreatorKey(asset.Creator, asset.UUID))
+	store.Delete(GetAssetByCategoryKey(asset.Metadata.Category, asset.UUID))
+}
@@ -1,36 +1,75 @@
 package asset
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/go-amino"
 
+	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
 	msg "github.com/sharering/shareledger/x/asset/messages"
+	"github.com/sharering/shareledger/x/booking"
 )
 
 // Keeper data type
 type Keeper struct {
-	storeKey sdk.StoreKey // key used to access the store from the Context.
-	cdc      *amino.Codec
+	storeKey      sdk.StoreKey   // key used to access the store from the Context.
+	bookingKeeper booking.Keeper // checks bookings before an asset is deleted
+	cdc           *amino.Codec
 }
 
 // NewKeeper - Returns the Keeper
-func NewKeeper(key sdk.StoreKey, cdc *amino.Codec) Keeper {
+func NewKeeper(key sdk.StoreKey, bk booking.Keeper, cdc *amino.Codec) Keeper {
 	return Keeper{
-		storeKey: key,
-		cdc:      cdc,
+		storeKey:      key,
+		bookingKeeper: bk,
+		cdc:           cdc,
 	}
 }
 
+// authorize - only the current creator of an asset can modify it
+func authorize(ctx sdk.Context, asset types.Asset) error {
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	if !bytes.Equal(signer, asset.Creator) {
+		return fmt.Errorf(constants.ASSET_UNAUTHORIZED,
+			utils.ByteToString(asset.Creator),
+			asset.UUID,
+			utils.ByteToString(signer))
+	}
+	return nil
+}
+
 //----------------------------------------------------------
 func (k Keeper) CreateAsset(ctx sdk.Context, msg msg.MsgCreate) (types.Asset, error) {
 
 	store := ctx.KVStore(k.storeKey)
 
+	if store.Has([]byte(msg.UUID)) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_ALREADY_EXISTS, msg.UUID)
+	}
+
+	// An asset can only be created on behalf of its signer
+	signer := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(signer, msg.Creator) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_CREATOR_MISMATCH,
+			utils.ByteToString(msg.Creator),
+			utils.ByteToString(signer))
+	}
+
 	asset := types.NewAsset(msg.UUID, msg.Creator, msg.Hash, msg.Status, msg.Fee)
+	asset.Deposit = msg.Deposit
+	asset.RefundPolicy = msg.RefundPolicy
+	asset.Metadata = msg.Metadata
+	asset.BookingMode = msg.BookingMode
+	asset.PricingRules = msg.PricingRules
+	asset.DevicePubKey = msg.DevicePubKey
 
 	assetBytes, err := json.Marshal(asset)
 
@@ -41,6 +80,7 @@ func (k Keeper) CreateAsset(ctx sdk.Context, msg msg.MsgCreate) (types.Asset, er
 
 	// Store to KVStore
 	store.Set([]byte(msg.UUID), assetBytes)
+	k.setIndexes(ctx, asset)
 
 	return asset, nil
 }
@@ -82,7 +122,32 @@ func (k Keeper) UpdateAsset(ctx sdk.Context, msg msg.MsgUpdate) (types.Asset, er
 		return types.Asset{}, errors.New("Asset decoding error")
 	}
 
-	asset = types.NewAsset(msg.UUID, msg.Creator, msg.Hash, msg.Status, msg.Fee)
+	if err := authorize(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
+
+	// Creator is kept, ownership only changes through TransferAsset
+	updated := types.NewAsset(msg.UUID, asset.Creator, msg.Hash, msg.Status, msg.Fee)
+	updated.Deposit = msg.Deposit
+	updated.RefundPolicy = msg.RefundPolicy
+	updated.Metadata = msg.Metadata
+	updated.BookingMode = msg.BookingMode
+	updated.PricingRules = msg.PricingRules
+	updated.DevicePubKey = msg.DevicePubKey
+
+	// Renters booked on the current terms
+	if !updated.SameBookingTerms(asset) && k.bookingKeeper.HasActiveBooking(ctx, asset.UUID) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_TERMS_LOCKED, asset.UUID)
+	}
+
+	// A legacy booking holds the asset through its status until the booking ends
+	if updated.Status && !asset.Status && k.bookingKeeper.HasLegacyBooking(ctx, asset.UUID) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_HELD_BY_LEGACY_BOOKING, asset.UUID)
+	}
+
+	k.removeIndexes(ctx, asset)
+
+	asset = updated
 
 	nassetBytes, err := json.Marshal(asset)
 
@@ -92,6 +157,7 @@ func (k Keeper) UpdateAsset(ctx sdk.Context, msg msg.MsgUpdate) (types.Asset, er
 	}
 
 	store.Set([]byte(msg.UUID), nassetBytes)
+	k.setIndexes(ctx, asset)
 
 	return asset, nil
 }
@@ -115,8 +181,58 @@ func (k Keeper) DeleteAsset(ctx sdk.Context, msg msg.MsgDelete) (types.Asset, er
 		return types.NewAsset("", []byte(""), []byte(""), true, 0), errors.New("Asset decoding error")
 	}
 
+	if err := authorize(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
+
+	if k.bookingKeeper.HasActiveBooking(ctx, asset.UUID) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_ACTIVE_BOOKING, asset.UUID)
+	}
+
 	// Delete asset
 	store.Delete([]byte(msg.UUID))
+	k.removeIndexes(ctx, asset)
+
+	return asset, nil
+}
+
+func (k Keeper) TransferAsset(ctx sdk.Context, msg msg.MsgTransferAsset) (types.Asset, error) {
+
+	store := ctx.KVStore(k.storeKey)
+
+	assetBytes := store.Get([]byte(msg.UUID))
+
+	if assetBytes == nil {
+		return types.Asset{}, errors.New("Asset is not found")
+	}
+
+	var asset types.Asset
+
+	derr := json.Unmarshal(assetBytes, &asset)
+	if derr != nil {
+		return types.Asset{}, errors.New("Asset decoding error")
+	}
+
+	if err := authorize(ctx, asset); err != nil {
+		return types.Asset{}, err
+	}
+
+	// Bookings pay out to the owner at settlement, they must be settled first
+	if k.bookingKeeper.HasActiveBooking(ctx, asset.UUID) {
+		return types.Asset{}, fmt.Errorf(constants.ASSET_ACTIVE_BOOKING, asset.UUID)
+	}
+
+	k.removeIndexes(ctx, asset)
+
+	asset.Creator = msg.NewOwner
+
+	nassetBytes, err := json.Marshal(asset)
+	if err != nil {
+		return types.Asset{}, errors.New("Asset Encoding Error")
+	}
+
+	store.Set([]byte(msg.UUID), nassetBytes)
+	k.setIndexes(ctx, asset)
 
 	return asset, nil
 }
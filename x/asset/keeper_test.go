@@ -0,0 +1,279 @@
+package asset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	msg "github.com/sharering/shareledger/x/asset/messages"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking"
+	bookingMsg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/exchange"
+)
+
+const (
+	testNow  = int64(1000000)
+	testHour = int64(60 * 60)
+)
+
+var (
+	testOwner  = sdk.AccAddress([]byte("owner_______________"))
+	testRenter = sdk.AccAddress([]byte("renter______________"))
+)
+
+type testInput struct {
+	ctx        sdk.Context
+	k          Keeper
+	bk         bank.Keeper
+	am         auth.AccountMapper
+	bookingKey *sdk.KVStoreKey
+	cdc        *amino.Codec
+}
+
+func setupTestInput(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+	bookingKey := sdk.NewKVStoreKey(constants.STORE_BOOKING)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, assetKey, bookingKey, bankKey, exchangeKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	bk := bank.NewKeeper(am, bankKey, cdc)
+	ek := exchange.NewKeeper(exchangeKey, bk)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(testNow, 0)}, false, log.NewNopLogger())
+
+	return testInput{
+		ctx:        ctx,
+		k:          NewKeeper(assetKey, booking.NewKeeper(bookingKey, assetKey, bk, ek, cdc), cdc),
+		bk:         bk,
+		am:         am,
+		bookingKey: bookingKey,
+		cdc:        cdc,
+	}
+}
+
+// signedBy returns the context of a transaction signed by *addr*
+func (in testInput) signedBy(addr sdk.AccAddress) sdk.Context {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		acc = auth.NewSHRAccountWithAddress(addr)
+	}
+	acc.SetNonce(acc.GetNonce() + 1)
+	in.am.SetAccount(in.ctx, acc)
+
+	return auth.WithSigners(in.ctx, acc)
+}
+
+// create stores an asset through MsgCreate signed by its creator
+func (in testInput) create(t *testing.T, create msg.MsgCreate) types.Asset {
+	asset, err := in.k.CreateAsset(in.signedBy(create.Creator), create)
+	if err != nil {
+		t.Fatalf("Creating asset failed. %s", err)
+	}
+	return asset
+}
+
+// book funds testRenter and books *uuid* for two hours
+func (in testInput) book(t *testing.T, uuid string) types.Booking {
+	amt := types.NewCoin(constants.BOOKING_DENOM, 100)
+	if _, err := in.bk.AddCoin(in.ctx, testRenter, amt); err != nil {
+		t.Fatalf("Funding failed. %s", err)
+	}
+	in.bk.AddSupply(in.ctx, amt)
+
+	bk, err := in.k.bookingKeeper.Book(in.signedBy(testRenter), bookingMsg.NewMsgBook(uuid, 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	return bk
+}
+
+// updateOf returns an update keeping every field of the asset
+func updateOf(asset types.Asset) msg.MsgUpdate {
+	update := msg.NewMsgUpdate(asset.Hash, asset.UUID, asset.Status, asset.Fee)
+	update.Deposit = asset.Deposit
+	update.RefundPolicy = asset.RefundPolicy
+	update.Metadata = asset.Metadata
+	update.BookingMode = asset.BookingMode
+	update.PricingRules = asset.PricingRules
+	update.DevicePubKey = asset.DevicePubKey
+	return update
+}
+
+func TestUpdateAssetTermsLocked(t *testing.T) {
+	in := setupTestInput(t)
+	asset := in.create(t, msg.NewMsgCreate(testOwner, []byte("hash"), "asset", true, 10))
+	booking := in.book(t, "asset")
+
+	priced := updateOf(asset)
+	priced.PricingRules = types.NewPricingRules(0, 0, nil, []types.Surcharge{{From: 0, To: constants.SECONDS_PER_WEEK, Percent: 50}})
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), priced); err == nil {
+		t.Errorf("Changing pricing rules of a booked asset should fail.")
+	}
+
+	device := updateOf(asset)
+	device.DevicePubKey = types.PubKeySecp256k1{0x02, 0x01}
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), device); err == nil {
+		t.Errorf("Changing the device of a booked asset should fail.")
+	}
+
+	// terms not affecting bookings can change
+	described := updateOf(asset)
+	described.Hash = []byte("new hash")
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), described); err != nil {
+		t.Errorf("Changing the hash of a booked asset failed. %s", err)
+	}
+
+	if _, err := in.k.TransferAsset(in.signedBy(testOwner), msg.NewMsgTransferAsset("asset", testRenter)); err == nil {
+		t.Errorf("Transferring a booked asset should fail.")
+	}
+
+	if _, err := in.k.bookingKeeper.Complete(in.signedBy(testOwner), bookingMsg.NewMsgComplete(booking.BookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), priced); err != nil {
+		t.Errorf("Changing pricing rules once bookings ended failed. %s", err)
+	}
+
+	transferred, err := in.k.TransferAsset(in.signedBy(testOwner), msg.NewMsgTransferAsset("asset", testRenter))
+	if err != nil || !transferred.Creator.Equals(testRenter) {
+		t.Errorf("Transferring an asset without bookings failed. %s", err)
+	}
+}
+
+func TestAssetHeldByLegacyBooking(t *testing.T) {
+	in := setupTestInput(t)
+	asset := in.create(t, msg.NewMsgCreate(testOwner, []byte("hash"), "asset", false, 10))
+
+	// booked before time windows: the booking cleared the asset status and has no slot
+	legacy := types.NewBooking("a1b2", testRenter, "asset", 3, 0, 0, false)
+	if err := utils.Store(in.ctx.KVStore(in.bookingKey), []byte(legacy.BookingID), legacy); err != nil {
+		t.Fatalf("Storing booking failed. %s", err)
+	}
+	in.k.bookingKeeper.MigrateLegacyBookings(in.ctx)
+
+	if !in.k.bookingKeeper.HasActiveBooking(in.ctx, "asset") {
+		t.Fatalf("Legacy booking should hold its asset.")
+	}
+
+	available := updateOf(asset)
+	available.Status = true
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), available); err == nil {
+		t.Errorf("Making an asset held by a legacy booking available should fail.")
+	}
+
+	if _, err := in.k.TransferAsset(in.signedBy(testOwner), msg.NewMsgTransferAsset("asset", testRenter)); err == nil {
+		t.Errorf("Transferring an asset held by a legacy booking should fail.")
+	}
+
+	if _, err := in.k.DeleteAsset(in.signedBy(testOwner), msg.NewMsgDelete("asset")); err == nil {
+		t.Errorf("Deleting an asset held by a legacy booking should fail.")
+	}
+
+	if _, err := in.k.bookingKeeper.Complete(in.signedBy(testRenter), bookingMsg.NewMsgComplete("a1b2")); err != nil {
+		t.Fatalf("Completing the legacy booking failed. %s", err)
+	}
+
+	if in.k.bookingKeeper.HasActiveBooking(in.ctx, "asset") {
+		t.Errorf("Completed legacy booking should release its asset.")
+	}
+}
+
+// createIn stores an asset of *creator* in *category*
+func (in testInput) createIn(t *testing.T, creator sdk.AccAddress, uuid string, category string) types.Asset {
+	create := msg.NewMsgCreate(creator, []byte("hash"), uuid, true, 10)
+	create.Metadata = types.NewAssetMetadata(category, "u4pruyd", "ipfs://"+uuid, "", constants.PRICING_HOUR)
+	return in.create(t, create)
+}
+
+func uuidsOf(assets []types.Asset) (uuids []string) {
+	for _, asset := range assets {
+		uuids = append(uuids, asset.UUID)
+	}
+	return uuids
+}
+
+func TestAssetMetadataIndexes(t *testing.T) {
+	in := setupTestInput(t)
+
+	in.createIn(t, testOwner, "a", "car")
+	in.createIn(t, testOwner, "b", "cars")
+	in.createIn(t, testRenter, "c", "car")
+
+	// categories sharing a prefix are kept apart
+	if uuids := uuidsOf(in.k.GetAssetsByCategory(in.ctx, "car", 1, 10)); len(uuids) != 2 || uuids[0] != "a" || uuids[1] != "c" {
+		t.Errorf("Category car should list a and c, got %v.", uuids)
+	}
+	if uuids := uuidsOf(in.k.GetAssetsByCreator(in.ctx, testOwner, 1, 10)); len(uuids) != 2 || uuids[0] != "a" || uuids[1] != "b" {
+		t.Errorf("Owner should have a and b, got %v.", uuids)
+	}
+
+	// pages start at 1
+	if uuids := uuidsOf(in.k.GetAssetsByCreator(in.ctx, testOwner, 2, 1)); len(uuids) != 1 || uuids[0] != "b" {
+		t.Errorf("Second page of one should be b, got %v.", uuids)
+	}
+
+	// updating the category moves the asset to the new one
+	a, _ := in.k.GetAsset(in.ctx, "a")
+	update := updateOf(a)
+	update.Metadata.Category = "bike"
+	if _, err := in.k.UpdateAsset(in.signedBy(testOwner), update); err != nil {
+		t.Fatalf("Updating asset failed. %s", err)
+	}
+
+	if uuids := uuidsOf(in.k.GetAssetsByCategory(in.ctx, "car", 1, 10)); len(uuids) != 1 || uuids[0] != "c" {
+		t.Errorf("Category car should only list c once a moved, got %v.", uuids)
+	}
+	if uuids := uuidsOf(in.k.GetAssetsByCategory(in.ctx, "bike", 1, 10)); len(uuids) != 1 || uuids[0] != "a" {
+		t.Errorf("Category bike should list a, got %v.", uuids)
+	}
+
+	// transferring moves the asset to the new creator
+	if _, err := in.k.TransferAsset(in.signedBy(testOwner), msg.NewMsgTransferAsset("b", testRenter)); err != nil {
+		t.Fatalf("Transferring asset failed. %s", err)
+	}
+	if uuids := uuidsOf(in.k.GetAssetsByCreator(in.ctx, testRenter, 1, 10)); len(uuids) != 2 || uuids[0] != "b" || uuids[1] != "c" {
+		t.Errorf("Renter should have b and c, got %v.", uuids)
+	}
+
+	// deleting drops the asset from every index
+	if _, err := in.k.DeleteAsset(in.signedBy(testRenter), msg.NewMsgDelete("c")); err != nil {
+		t.Fatalf("Deleting asset failed. %s", err)
+	}
+	if uuids := uuidsOf(in.k.GetAssetsByCategory(in.ctx, "car", 1, 10)); len(uuids) != 0 {
+		t.Errorf("Category car should be empty, got %v.", uuids)
+	}
+	if uuids := uuidsOf(in.k.GetAssets(in.ctx, 1, 10)); len(uuids) != 2 || uuids[0] != "a" || uuids[1] != "b" {
+		t.Errorf("Assets a and b should be left, got %v.", uuids)
+	}
+}
@@ -0,0 +1,42 @@
+package asset
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Assets themselves are stored under their UUID, which is printable.
+// Indexes live under single byte prefixes which never start a UUID.
+
+//nolint
+var (
+	// Keys for store prefixes
+	AssetsByCreatorKey  = []byte{0x01} // prefix for each key to an asset, by creator
+	AssetsByCategoryKey = []byte{0x02} // prefix for each key to an asset, by category
+	AssetKeyStart       = []byte{0x20} // lowest possible key of an asset, its UUID is printable
+)
+
+// gets the prefix for all assets of a creator
+func GetAssetsByCreatorPrefix(creator sdk.AccAddress) []byte {
+	return append(AssetsByCreatorKey, creator.Bytes()...)
+}
+
+// gets the key for an asset of a creator
+// VALUE: UUID
+func GetAssetByCreatorKey(creator sdk.AccAddress, uuid string) []byte {
+	return append(GetAssetsByCreatorPrefix(creator), []byte(uuid)...)
+}
+
+// gets the prefix for all assets of a category
+func GetAssetsByCategoryPrefix(category string) []byte {
+	bz := make([]byte, 2, 2+len(category))
+	binary.BigEndian.PutUint16(bz, uint16(len(category)))
+	return append(AssetsByCategoryKey, append(bz, []byte(category)...)...)
+}
+
+// gets the key for an asset of a category
+// VALUE: UUID
+func GetAssetByCategoryKey(category string, uuid string) []byte {
+	return append(GetAssetsByCategoryPrefix(category), []byte(uuid)...)
+}
@@ -16,6 +16,12 @@ type MsgCreate struct {
 	UUID    string      `json:"uuid"`
 	Status  bool        `json:"status"`
 	Fee     int64       `json:"fee"`
+	Deposit int64       `json:"deposit"`
+	RefundPolicy types.RefundPolicy `json:"refund_policy"`
+	Metadata types.AssetMetadata `json:"metadata"`
+	BookingMode string `json:"booking_mode"`
+	PricingRules types.PricingRules `json:"pricing_rules"`
+	DevicePubKey types.PubKeySecp256k1 `json:"device_pubkey"`
 }
 
 // enforce the msg type at compile time
@@ -46,6 +52,34 @@ func (msg MsgCreate) ValidateBasic() sdk.Error {
 		return sdk.ErrInvalidAddress("Invalid address")
 	}
 
+	if !types.IsValidAssetUUID(msg.UUID) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_UUID, msg.UUID))
+	}
+
+	if msg.Deposit < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_DEPOSIT, msg.Deposit))
+	}
+
+	if !msg.RefundPolicy.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_REFUND_POLICY, msg.RefundPolicy))
+	}
+
+	if err := msg.Metadata.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	if !types.IsValidBookingMode(msg.BookingMode) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_BOOKING_MODE, msg.BookingMode))
+	}
+
+	if err := msg.PricingRules.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	if !msg.DevicePubKey.IsZero() && !msg.DevicePubKey.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_DEVICE, msg.DevicePubKey.String()))
+	}
+
 	return nil
 }
 
@@ -74,7 +108,11 @@ func (msg MsgCreate) Tags() sdk.Tags {
 		AppendTag("asset.UUID", msg.UUID).
 		AppendTag("asset.Hash", fmt.Sprintf("%X", msg.Hash)).
 		AppendTag("asset.Status", strconv.FormatBool(msg.Status)).
-		AppendTag("asset.Fee", strconv.Itoa(int(msg.Fee)))
+		AppendTag("asset.Fee", strconv.Itoa(int(msg.Fee))).
+		AppendTag("asset.Deposit", strconv.FormatInt(msg.Deposit, 10)).
+		AppendTag("asset.RefundPolicy", msg.RefundPolicy.Kind).
+		AppendTag("asset.Category", msg.Metadata.Category).
+		AppendTag("asset.BookingMode", msg.BookingMode)
 }
 
 //------------------------------------------
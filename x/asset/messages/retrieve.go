@@ -8,6 +8,8 @@ import (
 	"github.com/sharering/shareledger/constants"
 )
 
+// MsgRetrieve - read an asset through a transaction.
+// Deprecated: query 'custom/asset/asset' instead, which costs no nonce nor fee.
 type MsgRetrieve struct {
 	UUID string `json:"uuid"`
 }
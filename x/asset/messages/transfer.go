@@ -0,0 +1,73 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+)
+
+// MsgTransferAsset - current creator hands an asset over to a new owner
+type MsgTransferAsset struct {
+	UUID     string         `json:"uuid"`
+	NewOwner sdk.AccAddress `json:"new_owner"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgTransferAsset{}
+
+func NewMsgTransferAsset(uuid string, newOwner sdk.AccAddress) MsgTransferAsset {
+	return MsgTransferAsset{
+		UUID:     uuid,
+		NewOwner: newOwner,
+	}
+}
+
+// Type Implements Msg
+func (msg MsgTransferAsset) Route() string {
+	return constants.MESSAGE_ASSET
+}
+
+// Type Implements Msg
+func (msg MsgTransferAsset) Type() string {
+	return constants.MESSAGE_ASSET
+}
+
+// ValidateBasic Implements Msg
+func (msg MsgTransferAsset) ValidateBasic() sdk.Error {
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid UUID")
+	}
+
+	if len(msg.NewOwner) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address")
+	}
+
+	return nil
+}
+
+func (msg MsgTransferAsset) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgTransferAsset) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgTransferAsset) String() string {
+	return fmt.Sprintf("Asset/MsgTransferAsset{%s}", msg.UUID)
+}
+
+func (msg MsgTransferAsset) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgTransferAsset) Tags() sdk.Tags {
+	return sdk.NewTags("msg.module", "asset").
+		AppendTag("msg.action", "transfer").
+		AppendTag("asset.UUID", msg.UUID).
+		AppendTag("asset.newOwner", msg.NewOwner.String())
+}
@@ -7,22 +7,28 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
 )
 
+// MsgUpdate - update an asset. Ownership only changes through MsgTransferAsset
 type MsgUpdate struct {
-	Creator sdk.AccAddress `json:"creator"`
 	Hash    []byte      `json:"hash"`
 	UUID    string      `json:"uuid"`
 	Status  bool        `json:"status"`
 	Fee     int64       `json:"fee"`
+	Deposit int64       `json:"deposit"`
+	RefundPolicy types.RefundPolicy `json:"refund_policy"`
+	Metadata types.AssetMetadata `json:"metadata"`
+	BookingMode string `json:"booking_mode"`
+	PricingRules types.PricingRules `json:"pricing_rules"`
+	DevicePubKey types.PubKeySecp256k1 `json:"device_pubkey"`
 }
 
 // enforce the msg type at compile time
 var _ sdk.Msg = MsgUpdate{}
 
-func NewMsgUpdate(creator sdk.AccAddress, hash []byte, uuid string, status bool, fee int64) MsgUpdate {
+func NewMsgUpdate(hash []byte, uuid string, status bool, fee int64) MsgUpdate {
 	return MsgUpdate{
-		Creator: creator,
 		Hash:    hash,
 		UUID:    uuid,
 		Fee:     fee,
@@ -42,8 +48,32 @@ func (msg MsgUpdate) Type() string {
 
 // ValidateBasic Implements Msg
 func (msg MsgUpdate) ValidateBasic() sdk.Error {
-	if len(msg.Creator) == 0 {
-		return sdk.ErrInvalidAddress("Invalid address")
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid UUID")
+	}
+
+	if msg.Deposit < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_DEPOSIT, msg.Deposit))
+	}
+
+	if !msg.RefundPolicy.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_REFUND_POLICY, msg.RefundPolicy))
+	}
+
+	if err := msg.Metadata.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	if !types.IsValidBookingMode(msg.BookingMode) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_BOOKING_MODE, msg.BookingMode))
+	}
+
+	if err := msg.PricingRules.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	if !msg.DevicePubKey.IsZero() && !msg.DevicePubKey.IsValid() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_DEVICE, msg.DevicePubKey.String()))
 	}
 
 	return nil
@@ -64,15 +94,18 @@ func (msg MsgUpdate) String() string {
 }
 
 func (msg MsgUpdate) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.Creator}
+	return []sdk.AccAddress{}
 }
 
 func (msg MsgUpdate) Tags() sdk.Tags {
 	return sdk.NewTags("msg.module", "asset").
 		AppendTag("msg.action", "update").
-		AppendTag("asset.creator", msg.Creator.String()).
 		AppendTag("asset.UUID", msg.UUID).
 		AppendTag("asset.Hash", fmt.Sprintf("%X", msg.Hash)).
 		AppendTag("asset.Status", strconv.FormatBool(msg.Status)).
-		AppendTag("asset.Fee", strconv.Itoa(int(msg.Fee)))
+		AppendTag("asset.Fee", strconv.Itoa(int(msg.Fee))).
+		AppendTag("asset.Deposit", strconv.FormatInt(msg.Deposit, 10)).
+		AppendTag("asset.RefundPolicy", msg.RefundPolicy.Kind).
+		AppendTag("asset.Category", msg.Metadata.Category).
+		AppendTag("asset.BookingMode", msg.BookingMode)
 }
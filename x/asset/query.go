@@ -0,0 +1,169 @@
+package asset
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// query endpoints supported by asset querier
+const (
+	QueryAsset      = "asset"
+	QueryByCreator  = "by-creator"
+	QueryByCategory = "by-category"
+	QueryList       = "list"
+)
+
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAsset:
+			return queryAsset(ctx, cdc, req, k)
+		case QueryByCreator:
+			return queryByCreator(ctx, cdc, req, k)
+		case QueryByCategory:
+			return queryByCategory(ctx, cdc, req, k)
+		case QueryList:
+			return queryList(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown asset query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/asset'
+type QueryAssetParams struct {
+	UUID string
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/by-creator'
+type QueryByCreatorParams struct {
+	Creator sdk.AccAddress
+	Page    int // starting from 1
+	Limit   int
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/by-category'
+type QueryByCategoryParams struct {
+	Category string
+	Page     int // starting from 1
+	Limit    int
+}
+
+// defines the params for the following queries:
+// - 'custom/asset/list'
+type QueryListParams struct {
+	Page  int // starting from 1
+	Limit int
+}
+
+func queryAsset(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryAssetParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryAssetParams"))
+	}
+
+	asset, found := k.GetAsset(ctx, params.UUID)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND,
+			params.UUID,
+			constants.STORE_ASSET))
+	}
+
+	res, errRes = cdc.MarshalJSON(asset)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "types.Asset"))
+	}
+	return res, nil
+}
+
+func queryByCreator(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryByCreatorParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("Malform address: %s", errRes.Error()))
+	}
+
+	page, limit, err := pagination(params.Page, params.Limit)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	assets := k.GetAssetsByCreator(ctx, params.Creator, page, limit)
+
+	res, errRes = cdc.MarshalJSON(assets)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Asset"))
+	}
+	return res, nil
+}
+
+func queryByCategory(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryByCategoryParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryByCategoryParams"))
+	}
+
+	page, limit, err := pagination(params.Page, params.Limit)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	assets := k.GetAssetsByCategory(ctx, params.Category, page, limit)
+
+	res, errRes = cdc.MarshalJSON(assets)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Asset"))
+	}
+	return res, nil
+}
+
+func queryList(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryListParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryListParams"))
+	}
+
+	page, limit, err := pagination(params.Page, params.Limit)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	assets := k.GetAssets(ctx, page, limit)
+
+	res, errRes = cdc.MarshalJSON(assets)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Asset"))
+	}
+	return res, nil
+}
+
+// pagination - apply defaults to page and limit of a query
+func pagination(page int, limit int) (int, int, sdk.Error) {
+	if page == 0 {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = constants.ASSET_DEFAULT_PAGE_LIMIT
+	}
+
+	if page < 0 || limit < 0 || limit > constants.ASSET_MAX_PAGE_LIMIT {
+		return 0, 0, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ASSET_INVALID_PAGINATION, page, limit))
+	}
+	return page, limit, nil
+}
@@ -0,0 +1,75 @@
+package asset
+
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// query runs the asset querier on *endpoint* with amino encoded *params*
+func (in testInput) query(t *testing.T, endpoint string, params interface{}, res interface{}) error {
+	querier := NewQuerier(in.k, in.cdc)
+
+	bz, err := querier(in.ctx, []string{endpoint}, abci.RequestQuery{Data: in.cdc.MustMarshalBinaryLengthPrefixed(params)})
+	if err != nil {
+		return err
+	}
+
+	if err := in.cdc.UnmarshalJSON(bz, res); err != nil {
+		t.Fatalf("Decoding %s result failed. %s", endpoint, err)
+	}
+	return nil
+}
+
+func TestQueryAssets(t *testing.T) {
+	in := setupTestInput(t)
+
+	in.createIn(t, testOwner, "a", "car")
+	in.createIn(t, testOwner, "b", "bike")
+	in.createIn(t, testRenter, "c", "car")
+
+	var asset types.Asset
+	if err := in.query(t, QueryAsset, QueryAssetParams{UUID: "b"}, &asset); err != nil {
+		t.Fatalf("Querying asset failed. %s", err)
+	}
+	if asset.UUID != "b" || asset.Metadata.Category != "bike" || !asset.Creator.Equals(testOwner) {
+		t.Errorf("Query should return asset b, got %+v.", asset)
+	}
+
+	if err := in.query(t, QueryAsset, QueryAssetParams{UUID: "d"}, &asset); err == nil {
+		t.Errorf("Querying a missing asset should fail.")
+	}
+
+	var assets []types.Asset
+	if err := in.query(t, QueryByCreator, QueryByCreatorParams{Creator: testOwner}, &assets); err != nil {
+		t.Fatalf("Querying by creator failed. %s", err)
+	}
+	if uuids := uuidsOf(assets); len(uuids) != 2 || uuids[0] != "a" || uuids[1] != "b" {
+		t.Errorf("Owner should have a and b, got %v.", uuids)
+	}
+
+	if err := in.query(t, QueryByCategory, QueryByCategoryParams{Category: "car"}, &assets); err != nil {
+		t.Fatalf("Querying by category failed. %s", err)
+	}
+	if uuids := uuidsOf(assets); len(uuids) != 2 || uuids[0] != "a" || uuids[1] != "c" {
+		t.Errorf("Category car should list a and c, got %v.", uuids)
+	}
+
+	if err := in.query(t, QueryList, QueryListParams{Page: 2, Limit: 2}, &assets); err != nil {
+		t.Fatalf("Listing assets failed. %s", err)
+	}
+	if uuids := uuidsOf(assets); len(uuids) != 1 || uuids[0] != "c" {
+		t.Errorf("Second page of two should be c, got %v.", uuids)
+	}
+
+	if err := in.query(t, QueryList, QueryListParams{Limit: constants.ASSET_MAX_PAGE_LIMIT + 1}, &assets); err == nil {
+		t.Errorf("Listing more than the page limit should fail.")
+	}
+
+	if err := in.query(t, "unknown", QueryListParams{}, &assets); err == nil {
+		t.Errorf("Querying an unknown endpoint should fail.")
+	}
+}
@@ -61,6 +61,19 @@ func (am AccountMapper) SetAccount(ctx sdk.Context, acc BaseAccount) {
 	store.Set(AddressToKey(addr), bz)
 }
 
+// IterateAccounts calls *process* on every account until it returns true
+func (am AccountMapper) IterateAccounts(ctx sdk.Context, process func(BaseAccount) (stop bool)) {
+	store := ctx.KVStore(am.key)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(constants.PREFIX_ADDRESS))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if process(am.decodeAccount(iterator.Value())) {
+			return
+		}
+	}
+}
+
 func (am AccountMapper) GetPubKey(ctx sdk.Context, addr sdk.AccAddress) (types.PubKey, sdk.Error) {
 	acc := am.GetAccount(ctx, addr)
 	if acc == nil {
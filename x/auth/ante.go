@@ -6,7 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/sharering/shareledger/constants"
 	//"github.com/tendermint/go-amino"
-	//"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/types"
 )
 
 func NewAnteHandler(am AccountMapper) sdk.AnteHandler {
@@ -19,6 +19,15 @@ func NewAnteHandler(am AccountMapper) sdk.AnteHandler {
 			return ctx, sdk.ErrInternal("tx must be AuthTx").Result(), true
 		}
 
+		if err := authTx.ValidateBasic(); err != nil {
+			return ctx, err.Result(), true
+		}
+
+		// Gas is metered from here, KV stores consume it as they are read and written.
+		// The transaction aborts when it runs out of gas.
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(authTx.GetGasLimit()))
+		ctx.GasMeter().ConsumeGas(constants.GAS_PER_TX_BYTE*uint64(len(ctx.TxBytes())), "txSize")
+
 		sig := authTx.GetSignature()
 		if sig == nil {
 			return ctx,
@@ -26,10 +35,19 @@ func NewAnteHandler(am AccountMapper) sdk.AnteHandler {
 				true
 		}
 
-		authSig, ok := sig.(AuthSig)
-		if !ok {
+		// a single key or a threshold of the keys of a multisig account sign
+		var authSig nonceSignature
+
+		switch sig := sig.(type) {
+		case AuthSig:
+			authSig = sig
+			ctx.GasMeter().ConsumeGas(constants.GAS_PER_SIGNATURE, "verifySignature")
+		case AuthMultiSig:
+			authSig = sig
+			ctx.GasMeter().ConsumeGas(constants.GAS_PER_SIGNATURE*uint64(len(sig.Signatures)), "verifySignature")
+		default:
 			return ctx,
-				sdk.ErrInternal("Sig must be AuthSig").Result(),
+				sdk.ErrInternal("Sig must be AuthSig or AuthMultiSig").Result(),
 				true
 		}
 
@@ -40,17 +58,46 @@ func NewAnteHandler(am AccountMapper) sdk.AnteHandler {
 			return ctx, res, true
 		}
 
+		// Under a grant, the granter is the signer handlers see.
+		// Every message of the transaction has to be granted.
+		if len(authTx.Granter) > 0 {
+			var granter BaseAccount
+
+			for _, msg := range authTx.GetMsgs() {
+				granter, res = verifyGrant(ctx, am, authTx.Granter, signingAccount.GetAddress(), msg)
+				if granter == nil {
+					return ctx, res, true
+				}
+			}
+
+			ctx = WithGrantee(ctx, signingAccount)
+			signingAccount = granter
+		}
+
 		// Save account to context
 		ctx = WithSigners(ctx, signingAccount)
 
-		return ctx, sdk.Result{}, false // abort = false
+		// messages are charged for the gas they use at the price of the transaction
+		ctx = WithTxGas(ctx, NewTxGas(authTx.GetGasPrice()))
+
+		// messages generating IDs draw from a sequence shared by the transaction
+		ctx = WithIDSeq(ctx)
+
+		return ctx, sdk.Result{GasWanted: authTx.GetGasLimit()}, false // abort = false
 
 	}
 }
 
+// nonceSignature - signature of an AuthTx whose key is the account signing
+type nonceSignature interface {
+	types.SHRSignature
+	GetPubKey() types.PubKey
+	GetNonce() int64
+}
+
 func verifySignature(ctx sdk.Context,
 	am AccountMapper,
-	sig AuthSig,
+	sig nonceSignature,
 	signBytes []byte,
 ) (acc BaseAccount, res sdk.Result) {
 
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -14,9 +16,16 @@ import (
 // AuthTx is of interface SHRTx
 var _ types.SHRTx = AuthTx{}
 
+// AuthTx carries either a single message or, in Msgs, a list of messages
+// which are executed in order and all succeed or all fail
 type AuthTx struct {
 	sdk.Msg   `json:"message"`
-	Signature AuthSig `json:"signature"`
+	Signature AuthSig        `json:"signature"`
+	Granter   sdk.AccAddress `json:"granter,omitempty"`   // account the signer acts for under a grant
+	Msgs      []sdk.Msg      `json:"messages,omitempty"`  // used instead of Msg for several messages
+	MultiSig  *AuthMultiSig  `json:"multisig,omitempty"`  // used instead of Signature by multisig accounts
+	GasLimit  uint64         `json:"gas_limit,omitempty"` // GAS_DEFAULT_LIMIT when 0
+	GasPrice  int64          `json:"gas_price,omitempty"` // in 10^-GAS_PRICE_PRECISION FEE_DENOM per gas, GAS_MIN_PRICE when 0
 }
 
 func NewAuthTx(msg sdk.Msg, sig AuthSig) AuthTx {
@@ -26,36 +35,192 @@ func NewAuthTx(msg sdk.Msg, sig AuthSig) AuthTx {
 	}
 }
 
+// NewMultiMsgAuthTx - transaction executing *msgs* in order, all or none
+func NewMultiMsgAuthTx(msgs []sdk.Msg, sig AuthSig) AuthTx {
+	return AuthTx{
+		Msgs:      msgs,
+		Signature: sig,
+	}
+}
+
+// NewGrantedAuthTx - transaction signed on behalf of *granter*
+func NewGrantedAuthTx(msg sdk.Msg, sig AuthSig, granter sdk.AccAddress) AuthTx {
+	return AuthTx{
+		Msg:       msg,
+		Signature: sig,
+		Granter:   granter,
+	}
+}
+
 // GetMsgs returns multiple messages
 func (tx AuthTx) GetMsgs() []sdk.Msg {
+	if len(tx.Msgs) > 0 {
+		return tx.Msgs
+	}
+	if tx.Msg == nil {
+		return nil
+	}
 	return []sdk.Msg{tx.Msg}
 }
 
-// GetMsg returns the message of this transaction
+// GetMsg returns the message of this transaction, the first one if it has several
 func (tx AuthTx) GetMsg() sdk.Msg {
+	if len(tx.Msgs) > 0 {
+		return tx.Msgs[0]
+	}
 	return tx.Msg
 }
 
+// ValidateBasic checks the transaction shape and every message it carries
+func (tx AuthTx) ValidateBasic() sdk.Error {
+	if tx.Msg != nil && len(tx.Msgs) > 0 {
+		return sdk.ErrUnknownRequest(constants.AUTH_MSG_AND_MSGS)
+	}
+
+	if tx.MultiSig != nil {
+		if tx.Signature.PubKey != nil {
+			return sdk.ErrUnknownRequest(constants.AUTH_SIG_AND_MULTISIG)
+		}
+		if err := tx.MultiSig.PubKey.Validate(); err != nil {
+			return sdk.ErrUnknownRequest(err.Error())
+		}
+	}
+
+	if tx.GasLimit > constants.GAS_MAX_LIMIT {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GAS_LIMIT_TOO_HIGH, tx.GasLimit, constants.GAS_MAX_LIMIT))
+	}
+
+	if tx.GasPrice != 0 && tx.GasPrice < constants.GAS_MIN_PRICE {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GAS_PRICE_TOO_LOW, tx.GasPrice, constants.GAS_MIN_PRICE))
+	}
+
+	msgs := tx.GetMsgs()
+
+	if len(msgs) == 0 {
+		return sdk.ErrUnknownRequest(constants.AUTH_EMPTY_TX)
+	}
+
+	if len(msgs) > constants.AUTH_MAX_MSGS {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.AUTH_TOO_MANY_MSGS, len(msgs), constants.AUTH_MAX_MSGS))
+	}
+
+	for _, msg := range msgs {
+		if msg == nil {
+			return sdk.ErrUnknownRequest(constants.AUTH_EMPTY_TX)
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSignature returns the signature with this transaction
 func (tx AuthTx) GetSignature() types.SHRSignature {
+	if tx.MultiSig != nil {
+		return *tx.MultiSig
+	}
 	return tx.Signature
 }
 
+// WithMultiSig returns the transaction signed by a multisig account
+func (tx AuthTx) WithMultiSig(sig AuthMultiSig) AuthTx {
+	tx.Signature = AuthSig{}
+	tx.MultiSig = &sig
+	return tx
+}
+
+// WithGas returns the transaction with a gas limit and a gas price, to be set before signing
+func (tx AuthTx) WithGas(limit uint64, price int64) AuthTx {
+	tx.GasLimit = limit
+	tx.GasPrice = price
+	return tx
+}
+
+// GetGasLimit returns the most gas the transaction can use
+func (tx AuthTx) GetGasLimit() uint64 {
+	if tx.GasLimit == 0 {
+		return constants.GAS_DEFAULT_LIMIT
+	}
+	return tx.GasLimit
+}
+
+// GetGasPrice returns the price the transaction pays for each gas it uses
+func (tx AuthTx) GetGasPrice() int64 {
+	if tx.GasPrice == 0 {
+		return constants.GAS_MIN_PRICE
+	}
+	return tx.GasPrice
+}
+
 // GetNonce returns Nonce sent with the signature
 func (tx AuthTx) GetNonce() int64 {
+	if tx.MultiSig != nil {
+		return tx.MultiSig.GetNonce()
+	}
 	return tx.Signature.GetNonce()
 }
 
-// GetSignBytes returns Bytes to be signed
+// GetSignBytes returns Bytes to be signed.
+// Under a grant, or with a gas limit or price set by the signer, they are signed
+// together with the messages as one JSON document. Its fields are delimited,
+// so the nonce prefixing the sign bytes cannot be moved into them.
 func (tx AuthTx) GetSignBytes() []byte {
-	return tx.Msg.GetSignBytes()
+	signBytes := tx.msgSignBytes()
+
+	if len(tx.Granter) == 0 && tx.GasLimit == 0 && tx.GasPrice == 0 {
+		return signBytes
+	}
+
+	doc := authSignDoc{
+		Type:     authSignDocType,
+		GasLimit: tx.GasLimit,
+		GasPrice: tx.GasPrice,
+		Msgs:     json.RawMessage(signBytes),
+	}
+	if len(tx.Granter) > 0 {
+		doc.Granter = tx.Granter.String()
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+const authSignDocType = "shareledger/AuthTx"
+
+// authSignDoc - what a transaction under a grant or with gas set is signed over
+type authSignDoc struct {
+	Type     string          `json:"type"`
+	Granter  string          `json:"granter,omitempty"`
+	GasLimit uint64          `json:"gas_limit"`
+	GasPrice int64           `json:"gas_price"`
+	Msgs     json.RawMessage `json:"msgs"`
+}
+
+// msgSignBytes returns the sign bytes of a single message as they are,
+// and those of several messages as a JSON array, in order
+func (tx AuthTx) msgSignBytes() []byte {
+	if len(tx.Msgs) == 0 {
+		return tx.Msg.GetSignBytes()
+	}
+
+	parts := make([][]byte, 0, len(tx.Msgs))
+	for _, msg := range tx.Msgs {
+		parts = append(parts, msg.GetSignBytes())
+	}
+
+	signBytes := append([]byte("["), bytes.Join(parts, []byte(","))...)
+	return append(signBytes, ']')
 }
 
 // VerifySignature to verify signature
 func (tx AuthTx) VerifySignature() bool {
 	msg := tx.GetSignBytes()
 	constants.LOGGER.Info("SignBytes", "signBytes", msg)
-	return tx.Signature.Verify(msg)
+	return tx.GetSignature().Verify(msg)
 }
 
 // JSON decode MsgSend.
@@ -137,3 +302,14 @@ func GetAuthTx(pubKey types.PubKey, privKey types.PrivKey, msg sdk.Msg, nonce in
 
 	return NewAuthTx(msg, authSig)
 }
+
+// GetMultiMsgAuthTx - create an AuthTx executing several messages
+func GetMultiMsgAuthTx(pubKey types.PubKey, privKey types.PrivKey, msgs []sdk.Msg, nonce int64) AuthTx {
+
+	// the transaction is signed as a whole, over the sign bytes of all messages
+	sig := privKey.SignWithNonce(NewMultiMsgAuthTx(msgs, AuthSig{}), nonce)
+
+	authSig := NewAuthSig(pubKey, sig, nonce)
+
+	return NewMultiMsgAuthTx(msgs, authSig)
+}
@@ -7,12 +7,20 @@ import (
 	//"fmt"
 
 	"github.com/btcsuite/btcd/btcec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	crypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/x/asset/messages"
 )
 
+func init() {
+	// signatures are verified by the node, which logs them
+	constants.LOGGER = log.NewNopLogger()
+}
+
 func TestTransaction(t *testing.T) {
 	pkBytes, err := hex.DecodeString("ab83994cf95abe45b9d8610524b3f8f8fd023d69f79449011cb5320d2ca180c5")
 
@@ -75,7 +83,7 @@ func TestTransaction(t *testing.T) {
 	}
 
 	tx := NewAuthTx(msgCreate, shrSig)
-	t.Logf("Nonce Signed Tx: %s\n", tx)
+	t.Logf("Nonce Signed Tx: %v\n", tx)
 	t.Log("Verify Signature:", tx.VerifySignature())
 
 	if !tx.VerifySignature() {
@@ -84,3 +92,111 @@ func TestTransaction(t *testing.T) {
 	}
 
 }
+
+func TestMultiMsgTransaction(t *testing.T) {
+	pkBytes, err := hex.DecodeString("ab83994cf95abe45b9d8610524b3f8f8fd023d69f79449011cb5320d2ca180c5")
+	if err != nil {
+		t.Fatal("Error in DecodeString: ", err)
+	}
+
+	privKey := types.NewPrivKeySecp256k1(pkBytes)
+	pubKey := privKey.PubKey()
+	address := pubKey.Address()
+
+	msgs := []sdk.Msg{
+		messages.NewMsgCreate(address, []byte("111111"), "112233", true, 1),
+		messages.NewMsgCreate(address, []byte("222222"), "445566", true, 2),
+	}
+
+	tx := GetMultiMsgAuthTx(pubKey, privKey, msgs, 1)
+
+	if len(tx.GetMsgs()) != 2 {
+		t.Errorf("Transaction should carry 2 messages, got %d.", len(tx.GetMsgs()))
+	}
+
+	if err := tx.ValidateBasic(); err != nil {
+		t.Errorf("Transaction should be valid. %s", err)
+	}
+
+	if !tx.VerifySignature() {
+		t.Error("Signature verification failed.")
+	}
+
+	// the signature covers every message and their order
+	swapped := NewMultiMsgAuthTx([]sdk.Msg{msgs[1], msgs[0]}, tx.Signature)
+	if swapped.VerifySignature() {
+		t.Error("Signature should not verify once messages are reordered.")
+	}
+
+	dropped := NewMultiMsgAuthTx(msgs[:1], tx.Signature)
+	if dropped.VerifySignature() {
+		t.Error("Signature should not verify once a message is dropped.")
+	}
+}
+
+func TestGasTransaction(t *testing.T) {
+	pkBytes, err := hex.DecodeString("ab83994cf95abe45b9d8610524b3f8f8fd023d69f79449011cb5320d2ca180c5")
+	if err != nil {
+		t.Fatal("Error in DecodeString: ", err)
+	}
+
+	privKey := types.NewPrivKeySecp256k1(pkBytes)
+	pubKey := privKey.PubKey()
+
+	msg := messages.NewMsgCreate(pubKey.Address(), []byte("111111"), "112233", true, 1)
+
+	plain := NewAuthTx(msg, AuthSig{})
+	if plain.GetGasLimit() != constants.GAS_DEFAULT_LIMIT || plain.GetGasPrice() != constants.GAS_MIN_PRICE {
+		t.Error("Transaction without gas should use the default limit and the minimum price.")
+	}
+
+	tx := plain.WithGas(500000, 2*constants.GAS_MIN_PRICE)
+	tx.Signature = NewAuthSig(pubKey, privKey.SignBytesWithNonce(tx.GetSignBytes(), 1), 1)
+
+	if err := tx.ValidateBasic(); err != nil {
+		t.Errorf("Transaction should be valid. %s", err)
+	}
+
+	if !tx.VerifySignature() {
+		t.Error("Signature verification failed.")
+	}
+
+	// the signer agreed to its gas price
+	if tx.WithGas(500000, 3*constants.GAS_MIN_PRICE).VerifySignature() {
+		t.Error("Signature should not verify once the gas price is changed.")
+	}
+
+	// the nonce prefixing the sign bytes cannot take digits from the gas limit,
+	// nonce 1 and gas limit 500000 against nonce 150000 and gas limit 0
+	replayed := tx.WithGas(0, 2*constants.GAS_MIN_PRICE)
+	replayed.Signature = NewAuthSig(pubKey, tx.Signature.Signature, 150000)
+	if replayed.VerifySignature() {
+		t.Error("Signature should not verify under another nonce and gas limit.")
+	}
+
+	if tx.WithGas(constants.GAS_MAX_LIMIT+1, 0).ValidateBasic() == nil {
+		t.Error("Gas limit above the maximum should be rejected.")
+	}
+
+	if tx.WithGas(0, constants.GAS_MIN_PRICE-1).ValidateBasic() == nil {
+		t.Error("Gas price below the minimum should be rejected.")
+	}
+}
+
+func TestTxGas(t *testing.T) {
+	gas := NewTxGas(constants.GAS_MIN_PRICE)
+
+	if used := gas.Charge(3000); used != 3000 {
+		t.Errorf("First charge should be 3000 gas, got %d.", used)
+	}
+
+	if used := gas.Charge(5000); used != 2000 {
+		t.Errorf("Second charge should be the 2000 gas used since the first, got %d.", used)
+	}
+
+	// 10^6 gas at 10^-6 per gas
+	fee := gas.Fee(1000000)
+	if !fee.Equal(types.NewCoin(constants.FEE_DENOM, 1)) {
+		t.Errorf("Fee should be 1%s, got %s.", constants.FEE_DENOM, fee.String())
+	}
+}
@@ -4,5 +4,7 @@ import "github.com/tendermint/go-amino"
 
 func RegisterCodec(cdc *amino.Codec) *amino.Codec {
 	cdc.RegisterConcrete(MsgNonce{}, "shareledger/auth/MsgNonce", nil)
+	cdc.RegisterConcrete(MsgGrant{}, "shareledger/auth/MsgGrant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "shareledger/auth/MsgRevoke", nil)
 	return cdc
 }
@@ -8,6 +8,9 @@ type contextKey int // local to auth module
 
 const (
 	contextKeySigner contextKey = iota
+	contextKeyGrantee
+	contextKeyGas
+	contextKeyIDSeq
 )
 
 // WithSigners add the signer to the context
@@ -24,3 +27,48 @@ func GetSigner(ctx sdk.Context) BaseAccount {
 	}
 	return v.(BaseAccount)
 }
+
+// WithGrantee adds the account which signed the transaction under a grant of the signer
+func WithGrantee(ctx sdk.Context, account BaseAccount) sdk.Context {
+	return ctx.WithValue(contextKeyGrantee, account)
+}
+
+// Get the grantee from the context, nil when the signer signed for itself
+func GetGrantee(ctx sdk.Context) BaseAccount {
+	v := ctx.Value(contextKeyGrantee)
+	if v == nil {
+		return nil
+	}
+	return v.(BaseAccount)
+}
+
+// WithTxGas adds the gas price of the transaction, its messages are charged at this price
+func WithTxGas(ctx sdk.Context, gas *TxGas) sdk.Context {
+	return ctx.WithValue(contextKeyGas, gas)
+}
+
+// Get the gas price of the transaction from the context, nil when gas is not charged
+func GetTxGas(ctx sdk.Context) *TxGas {
+	v := ctx.Value(contextKeyGas)
+	if v == nil {
+		return nil
+	}
+	return v.(*TxGas)
+}
+
+// WithIDSeq adds a counter of the IDs generated by the messages of the transaction
+func WithIDSeq(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(contextKeyIDSeq, new(int64))
+}
+
+// Get the next sequence number for an ID generated in the transaction.
+// Messages of a transaction share signer and nonce, the sequence tells their IDs apart.
+func NextIDSeq(ctx sdk.Context) int64 {
+	v := ctx.Value(contextKeyIDSeq)
+	if v == nil {
+		return 0
+	}
+	seq := v.(*int64)
+	*seq++
+	return *seq
+}
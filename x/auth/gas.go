@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// TxGas - gas price of a transaction and the gas it already paid for.
+// Fees are charged after each message, for the gas used since the previous one.
+type TxGas struct {
+	Price int64 // in 10^-GAS_PRICE_PRECISION FEE_DENOM per gas
+	paid  uint64
+}
+
+func NewTxGas(price int64) *TxGas {
+	return &TxGas{Price: price}
+}
+
+// Charge returns the gas used since the last charge, *consumed* is what the transaction used so far
+func (g *TxGas) Charge(consumed uint64) uint64 {
+	if consumed < g.paid {
+		return 0
+	}
+
+	used := consumed - g.paid
+	g.paid = consumed
+	return used
+}
+
+// Fee - what *gas* costs at the gas price, in FEE_DENOM
+func (g *TxGas) Fee(gas uint64) types.Coin {
+	price := types.NewDecWithPrec(g.Price, constants.GAS_PRICE_PRECISION)
+	return types.NewCoinFromDec(constants.FEE_DENOM, price.MulInt(types.NewInt(int64(gas))))
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// Grant - right of a grantee to sign some message types on behalf of a granter,
+// spending at most SpendLimit of the granter coins
+type Grant struct {
+	Granter    sdk.AccAddress `json:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee"`
+	MsgTypes   []string       `json:"msg_types"`   // route and type names, as in GRANTABLE_MSGS
+	SpendLimit types.Coins    `json:"spend_limit"` // what the grantee can still spend
+	Expiration int64          `json:"expiration"`  // unix time, 0 for no expiration
+}
+
+func NewGrant(granter sdk.AccAddress, grantee sdk.AccAddress, msgTypes []string,
+	spendLimit types.Coins, expiration int64) Grant {
+
+	// Denoms left out of the limit cannot be spent
+	limit := types.NewDefaultCoins()
+	for _, c := range spendLimit {
+		limit = limit.Plus(c)
+	}
+
+	return Grant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypes:   msgTypes,
+		SpendLimit: limit,
+		Expiration: expiration,
+	}
+}
+
+// Allows - whether the grantee can sign *msgType*
+func (g Grant) Allows(msgType string) bool {
+	for _, t := range g.MsgTypes {
+		if t == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired - whether the grant is over at unix time *now*
+func (g Grant) IsExpired(now int64) bool {
+	return g.Expiration != 0 && now >= g.Expiration
+}
+
+// Spend - grant once *amt* is taken off its spend limit
+func (g Grant) Spend(amt types.Coin) (Grant, error) {
+	remaining := g.SpendLimit.Minus(amt)
+
+	if !amt.HasValidDenom() || !remaining.IsNotNegative() {
+		return Grant{}, fmt.Errorf(constants.GRANT_SPEND_LIMIT_EXCEEDED,
+			amt.String(),
+			g.SpendLimit.String(),
+			utils.ByteToString(g.Granter),
+			utils.ByteToString(g.Grantee))
+	}
+
+	g.SpendLimit = remaining
+	return g, nil
+}
+
+//--------------------------------------------------------
+
+func GrantKey(granter sdk.AccAddress, grantee sdk.AccAddress) []byte {
+	return append(GrantPrefix(granter), grantee.Bytes()...)
+}
+
+// GrantPrefix - prefix of all grants given by *granter*
+func GrantPrefix(granter sdk.AccAddress) []byte {
+	return append([]byte(constants.PREFIX_GRANT), granter.Bytes()...)
+}
+
+// GetGrant returns the grant *granter* gave to *grantee*
+func (am AccountMapper) GetGrant(ctx sdk.Context, granter sdk.AccAddress, grantee sdk.AccAddress) (Grant, bool) {
+	store := ctx.KVStore(am.key)
+	bz := store.Get(GrantKey(granter, grantee))
+	if bz == nil {
+		return Grant{}, false
+	}
+
+	var grant Grant
+	am.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// GetGrants returns all grants given by *granter*
+func (am AccountMapper) GetGrants(ctx sdk.Context, granter sdk.AccAddress) (grants []Grant) {
+	store := ctx.KVStore(am.key)
+	iterator := sdk.KVStorePrefixIterator(store, GrantPrefix(granter))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		am.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+func (am AccountMapper) SetGrant(ctx sdk.Context, grant Grant) {
+	store := ctx.KVStore(am.key)
+	store.Set(GrantKey(grant.Granter, grant.Grantee), am.cdc.MustMarshalBinaryLengthPrefixed(grant))
+}
+
+func (am AccountMapper) DeleteGrant(ctx sdk.Context, granter sdk.AccAddress, grantee sdk.AccAddress) {
+	store := ctx.KVStore(am.key)
+	store.Delete(GrantKey(granter, grantee))
+}
+
+// SpendGrant - charge *amt* to the grant the transaction was signed under.
+// Nothing is charged when the signer acts for itself.
+func (am AccountMapper) SpendGrant(ctx sdk.Context, amt types.Coin) sdk.Error {
+	grantee := GetGrantee(ctx)
+	if grantee == nil {
+		return nil
+	}
+
+	return am.ChargeGrant(ctx, GetSigner(ctx).GetAddress(), grantee.GetAddress(), amt)
+}
+
+// ChargeGrant - charge *amt* to the grant *granter* gave to *grantee*, which must still hold
+func (am AccountMapper) ChargeGrant(ctx sdk.Context, granter sdk.AccAddress, grantee sdk.AccAddress, amt types.Coin) sdk.Error {
+	grant, found := am.GetGrant(ctx, granter, grantee)
+	if !found {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.GRANT_NOT_FOUND,
+			utils.ByteToString(grantee),
+			utils.ByteToString(granter)))
+	}
+
+	if grant.IsExpired(ctx.BlockHeader().Time.Unix()) {
+		return sdk.ErrUnauthorized(fmt.Sprintf(constants.GRANT_EXPIRED,
+			utils.ByteToString(granter),
+			utils.ByteToString(grantee),
+			grant.Expiration))
+	}
+
+	grant, err := grant.Spend(amt)
+	if err != nil {
+		return sdk.ErrUnauthorized(err.Error())
+	}
+
+	am.SetGrant(ctx, grant)
+	return nil
+}
+
+// verifyGrant checks *grantee* may sign *msg* for *granter* and returns the granter account
+func verifyGrant(ctx sdk.Context,
+	am AccountMapper,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	msg sdk.Msg,
+) (acc BaseAccount, res sdk.Result) {
+
+	grant, found := am.GetGrant(ctx, granter, grantee)
+	if !found {
+		return nil,
+			sdk.ErrUnauthorized(fmt.Sprintf(constants.GRANT_NOT_FOUND,
+				utils.ByteToString(grantee),
+				utils.ByteToString(granter))).Result()
+	}
+
+	if grant.IsExpired(ctx.BlockHeader().Time.Unix()) {
+		return nil,
+			sdk.ErrUnauthorized(fmt.Sprintf(constants.GRANT_EXPIRED,
+				utils.ByteToString(granter),
+				utils.ByteToString(grantee),
+				grant.Expiration)).Result()
+	}
+
+	msgType := utils.GetMsgKey(msg)
+	if !constants.GRANTABLE_MSGS[msgType] || !grant.Allows(msgType) {
+		return nil,
+			sdk.ErrUnauthorized(fmt.Sprintf(constants.GRANT_MSG_NOT_ALLOWED,
+				utils.ByteToString(granter),
+				utils.ByteToString(grantee),
+				msgType)).Result()
+	}
+
+	acc = am.GetAccount(ctx, granter)
+	if acc == nil {
+		return nil,
+			sdk.ErrUnknownAddress(fmt.Sprintf(constants.GRANT_UNKNOWN_GRANTER,
+				utils.ByteToString(granter))).Result()
+	}
+
+	return acc, sdk.Result{}
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth/tags"
+)
+
+var _ sdk.Msg = MsgGrant{}
+
+// MsgGrant - signer lets *Grantee* sign *MsgTypes* on its behalf.
+// A new grant replaces the previous one given to the same grantee.
+type MsgGrant struct {
+	Grantee    sdk.AccAddress `json:"grantee"`
+	MsgTypes   []string       `json:"msg_types"`   // e.g. booking/MsgBook, booking/MsgComplete, bank/MsgSend
+	SpendLimit types.Coins    `json:"spend_limit"` // most the grantee can spend of the signer coins
+	Expiration int64          `json:"expiration"`  // unix time, 0 for no expiration
+}
+
+func NewMsgGrant(grantee sdk.AccAddress, msgTypes []string, spendLimit types.Coins, expiration int64) MsgGrant {
+	return MsgGrant{
+		Grantee:    grantee,
+		MsgTypes:   msgTypes,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+func (msg MsgGrant) Route() string {
+	return constants.MESSAGE_AUTH
+}
+
+func (msg MsgGrant) Type() string {
+	return constants.MESSAGE_AUTH
+}
+
+func (msg MsgGrant) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress("Invalid grantee")
+	}
+
+	if len(msg.MsgTypes) == 0 || len(msg.MsgTypes) > constants.GRANT_MAX_MSG_TYPES {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_INVALID_MSG_TYPE, strings.Join(msg.MsgTypes, ",")))
+	}
+
+	for _, t := range msg.MsgTypes {
+		if !constants.GRANTABLE_MSGS[t] {
+			return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_INVALID_MSG_TYPE, t))
+		}
+	}
+
+	for _, c := range msg.SpendLimit {
+		if !c.HasValidDenom() || c.IsNil() || !c.IsNotNegative() {
+			return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_INVALID_SPEND_LIMIT, msg.SpendLimit.String()))
+		}
+	}
+
+	if msg.Expiration < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_INVALID_EXPIRATION, msg.Expiration, 0))
+	}
+
+	return nil
+}
+
+func (msg MsgGrant) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgGrant) String() string {
+	return fmt.Sprintf("Auth/MsgGrant{Grantee: %s, MsgTypes: %v, SpendLimit: %s, Expiration: %d}",
+		msg.Grantee, msg.MsgTypes, msg.SpendLimit.String(), msg.Expiration)
+}
+
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgGrant) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.Granted).
+		AppendTag(tags.Grantee, msg.Grantee.String()).
+		AppendTag(tags.MsgTypes, strings.Join(msg.MsgTypes, ","))
+}
+
+//--------------------------------------------------------
+
+var _ sdk.Msg = MsgRevoke{}
+
+// MsgRevoke - signer takes back the grant it gave to *Grantee*
+type MsgRevoke struct {
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+func NewMsgRevoke(grantee sdk.AccAddress) MsgRevoke {
+	return MsgRevoke{grantee}
+}
+
+func (msg MsgRevoke) Route() string {
+	return constants.MESSAGE_AUTH
+}
+
+func (msg MsgRevoke) Type() string {
+	return constants.MESSAGE_AUTH
+}
+
+func (msg MsgRevoke) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress("Invalid grantee")
+	}
+	return nil
+}
+
+func (msg MsgRevoke) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgRevoke) String() string {
+	return fmt.Sprintf("Auth/MsgRevoke{%s}", msg.Grantee)
+}
+
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgRevoke) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.Revoked).
+		AppendTag(tags.Grantee, msg.Grantee.String())
+}
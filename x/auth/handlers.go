@@ -1,12 +1,16 @@
 package auth
 
 import (
+	"bytes"
+	"fmt"
 	"reflect"
 	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth/tags"
 
 	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
 )
@@ -24,13 +28,17 @@ func NewHandler(am AccountMapper) sdkTypes.Handler {
 		switch msg := msg.(type) {
 		case MsgNonce:
 			ret = handleNonceQuery(ctx, am, msg)
+		case MsgGrant:
+			ret = handleGrant(ctx, am, msg)
+		case MsgRevoke:
+			ret = handleRevoke(ctx, am, msg)
 		default:
 			errMsg := "Unrecognized Auth Msg type" + reflect.TypeOf(msg).Name()
 			ret = sdk.ErrUnknownRequest(errMsg).Result()
 		}
-		return sdkTypes.Result{
-			Result: ret,
-		}
+
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(ret)
 	}
 }
 
@@ -44,3 +52,40 @@ func handleNonceQuery(ctx sdk.Context, am AccountMapper, msg MsgNonce) sdk.Resul
 		Tags: msg.Tags(),
 	}
 }
+
+func handleGrant(ctx sdk.Context, am AccountMapper, msg MsgGrant) sdk.Result {
+	granter := GetSigner(ctx).GetAddress()
+
+	if bytes.Equal(granter, msg.Grantee) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_SELF, utils.ByteToString(granter))).Result()
+	}
+
+	now := ctx.BlockHeader().Time.Unix()
+	if msg.Expiration != 0 && msg.Expiration <= now {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_INVALID_EXPIRATION, msg.Expiration, now)).Result()
+	}
+
+	grant := NewGrant(granter, msg.Grantee, msg.MsgTypes, msg.SpendLimit, msg.Expiration)
+	am.SetGrant(ctx, grant)
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("%+v", grant),
+		Tags: msg.Tags().AppendTag(tags.Granter, granter.String()),
+	}
+}
+
+func handleRevoke(ctx sdk.Context, am AccountMapper, msg MsgRevoke) sdk.Result {
+	granter := GetSigner(ctx).GetAddress()
+
+	if _, found := am.GetGrant(ctx, granter, msg.Grantee); !found {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.GRANT_NOT_FOUND,
+			utils.ByteToString(msg.Grantee),
+			utils.ByteToString(granter))).Result()
+	}
+
+	am.DeleteGrant(ctx, granter, msg.Grantee)
+
+	return sdk.Result{
+		Tags: msg.Tags().AppendTag(tags.Granter, granter.String()),
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+//-------------------------------------------------------------------
+// AuthMultiSig
+
+var _ types.SHRSignature = AuthMultiSig{}
+
+// AuthMultiSig - signature of a multisig account, made of the partial signatures
+// of the keys which signed, each an AuthSig over the same nonce and sign bytes
+type AuthMultiSig struct {
+	PubKey     types.PubKeyMultisig `json:"pub_key"`
+	Signatures []AuthSig            `json:"signatures"`
+	Nonce      int64                `json:"nonce"`
+}
+
+func NewAuthMultiSig(key types.PubKeyMultisig, sigs []AuthSig, nonce int64) AuthMultiSig {
+	return AuthMultiSig{
+		PubKey:     key,
+		Signatures: sigs,
+		Nonce:      nonce,
+	}
+}
+
+func (sig AuthMultiSig) String() string {
+	return fmt.Sprintf("AuthMultiSig{%s, %v, %d}", sig.PubKey, sig.Signatures, sig.Nonce)
+}
+
+// Verify - whether at least the threshold of keys signed the message, prefixed with the nonce
+func (sig AuthMultiSig) Verify(msg []byte) bool {
+	multisig, err := sig.Assemble()
+	if err != nil {
+		return false
+	}
+
+	nonceBytes := []byte(strconv.Itoa(int(sig.Nonce)))
+
+	return sig.PubKey.VerifyBytes(append(nonceBytes, msg...), multisig)
+}
+
+// Assemble - place every partial signature at the position of its key
+func (sig AuthMultiSig) Assemble() (types.SignatureMultisig, error) {
+	multisig := types.NewSignatureMultisig(len(sig.PubKey.PubKeys))
+
+	for _, partial := range sig.Signatures {
+		if partial.Nonce != sig.Nonce {
+			return nil, fmt.Errorf(constants.MULTISIG_NONCE_MISMATCH, partial.Nonce, sig.Nonce)
+		}
+
+		i := sig.PubKey.IndexOf(partial.PubKey)
+		if i < 0 {
+			return nil, fmt.Errorf(constants.MULTISIG_NOT_MEMBER, partial.PubKey, sig.PubKey)
+		}
+
+		if !multisig[i].IsZero() {
+			return nil, fmt.Errorf(constants.MULTISIG_DUPLICATE_KEY, partial.PubKey)
+		}
+
+		secpSig, ok := partial.Signature.(types.SignatureSecp256k1)
+		if !ok {
+			return nil, fmt.Errorf(constants.MULTISIG_INVALID_KEY, partial.PubKey)
+		}
+		multisig[i] = secpSig
+	}
+	return multisig, nil
+}
+
+func (sig AuthMultiSig) GetPubKey() types.PubKey {
+	return sig.PubKey
+}
+
+// GetNonce returns Nonce from Signature
+func (sig AuthMultiSig) GetNonce() int64 {
+	return sig.Nonce
+}
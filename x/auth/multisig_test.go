@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/sharering/shareledger/types"
+)
+
+func TestMultisigVerifyBytes(t *testing.T) {
+	var pubKeys []types.PubKeySecp256k1
+	var privKeys []types.PrivKeySecp256k1
+
+	for i := 0; i < 3; i++ {
+		pub, priv := types.GenerateKeyPair()
+		pubKeys = append(pubKeys, pub)
+		privKeys = append(privKeys, priv)
+	}
+
+	pubKey := types.NewPubKeyMultisig(2, pubKeys)
+	if err := pubKey.Validate(); err != nil {
+		t.Fatalf("Multisig key should be valid. %s", err)
+	}
+
+	msg := []byte("1{\"amount\":1}")
+
+	sig := types.NewSignatureMultisig(3)
+	sig[0] = privKeys[0].SignBytesWithNonce([]byte("{\"amount\":1}"), 1).(types.SignatureSecp256k1)
+
+	if pubKey.VerifyBytes(msg, sig) {
+		t.Error("A single signature should not reach a threshold of 2.")
+	}
+
+	sig[2] = privKeys[2].SignBytesWithNonce([]byte("{\"amount\":1}"), 1).(types.SignatureSecp256k1)
+
+	if !pubKey.VerifyBytes(msg, sig) {
+		t.Error("Two signatures should reach a threshold of 2.")
+	}
+
+	if pubKey.VerifyBytes([]byte("1{\"amount\":2}"), sig) {
+		t.Error("Signatures should not verify another message.")
+	}
+}
+
+func TestMultisigAddress(t *testing.T) {
+	pub1, _ := types.GenerateKeyPair()
+	pub2, _ := types.GenerateKeyPair()
+
+	oneOfTwo := types.NewPubKeyMultisig(1, []types.PubKeySecp256k1{pub1, pub2})
+	twoOfTwo := types.NewPubKeyMultisig(2, []types.PubKeySecp256k1{pub1, pub2})
+
+	if oneOfTwo.Address().Equals(twoOfTwo.Address()) {
+		t.Error("Threshold should change the address.")
+	}
+
+	if !twoOfTwo.Address().Equals(types.NewPubKeyMultisig(2, []types.PubKeySecp256k1{pub1, pub2}).Address()) {
+		t.Error("Same keys and threshold should give the same address.")
+	}
+}
+
+func TestMultisigValidate(t *testing.T) {
+	pub1, _ := types.GenerateKeyPair()
+	pub2, _ := types.GenerateKeyPair()
+
+	table := []struct {
+		pubKey types.PubKeyMultisig
+		valid  bool
+	}{
+		{types.NewPubKeyMultisig(1, []types.PubKeySecp256k1{pub1, pub2}), true},
+		{types.NewPubKeyMultisig(0, []types.PubKeySecp256k1{pub1, pub2}), false},
+		{types.NewPubKeyMultisig(3, []types.PubKeySecp256k1{pub1, pub2}), false},
+		{types.NewPubKeyMultisig(1, []types.PubKeySecp256k1{pub1, pub1}), false},
+		{types.NewPubKeyMultisig(1, []types.PubKeySecp256k1{pub1, types.PubKeySecp256k1{}}), false},
+	}
+
+	for i, tc := range table {
+		err := tc.pubKey.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d should be valid: %t, got %v.", i, tc.valid, err)
+		}
+	}
+}
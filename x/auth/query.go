@@ -11,7 +11,8 @@ import (
 
 // query endpoints supported by auth querier
 const (
-	QueryNonce = "nonce"
+	QueryNonce  = "nonce"
+	QueryGrants = "grants"
 )
 
 func NewQuerier(am AccountMapper, cdc *amino.Codec) sdk.Querier {
@@ -19,6 +20,8 @@ func NewQuerier(am AccountMapper, cdc *amino.Codec) sdk.Querier {
 		switch path[0] {
 		case QueryNonce:
 			return queryNonce(ctx, cdc, req, am)
+		case QueryGrants:
+			return queryGrants(ctx, cdc, req, am)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
 		}
@@ -29,6 +32,10 @@ type QueryNonceParams struct {
 	Address sdk.AccAddress
 }
 
+type QueryGrantsParams struct {
+	Granter sdk.AccAddress
+}
+
 func queryNonce(
 	ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, am AccountMapper,
 ) (res []byte, err sdk.Error) {
@@ -51,3 +58,23 @@ func queryNonce(
 
 	return res, nil
 }
+
+func queryGrants(
+	ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, am AccountMapper,
+) (res []byte, err sdk.Error) {
+	var params QueryGrantsParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("Malform address: %s", errRes.Error()))
+	}
+
+	grants := am.GetGrants(ctx, params.Granter)
+
+	res, err1 := json.Marshal(grants)
+	if err1 != nil {
+		return []byte{}, sdk.ErrInternal(fmt.Sprintf("couldnot marshal result to JSON: %s", err1.Error()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,15 @@
+package tags
+
+var (
+	//Key - String type
+
+	Event    = "Event"
+	Granter  = "Granter"
+	Grantee  = "Grantee"
+	MsgTypes = "MsgTypes"
+
+	//Value -  []byte
+
+	Granted = "Granted"
+	Revoked = "Revoked"
+)
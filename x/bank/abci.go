@@ -0,0 +1,40 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// BeginBlocker - a chain upgrading to supply tracking has no supply stored yet.
+// It is set from what accounts hold at the first block on this version, before any transaction changes it.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	if k.HasSupply(ctx) {
+		return
+	}
+
+	k.setSupply(ctx, k.SumAccounts(ctx))
+}
+
+// EndBlocker - checks the total supply every SUPPLY_INVARIANT_PERIOD blocks.
+// Summing all accounts reads every account of the auth store within a single block,
+// the period keeps that cost off most blocks. A mismatch halts the chain only if SUPPLY_INVARIANT_HALT is set.
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	if constants.SUPPLY_INVARIANT_PERIOD <= 0 || ctx.BlockHeight()%constants.SUPPLY_INVARIANT_PERIOD != 0 {
+		return
+	}
+
+	err := k.CheckSupply(ctx)
+	if err == nil {
+		return
+	}
+
+	if constants.SUPPLY_INVARIANT_HALT {
+		panic(err)
+	}
+
+	constants.LOGGER.Error("Supply invariant broken",
+		"height", ctx.BlockHeight(),
+		"err", err.Error(),
+	)
+}
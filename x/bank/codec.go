@@ -10,5 +10,7 @@ func RegisterCodec(cdc *amino.Codec) *amino.Codec {
 	cdc.RegisterConcrete(msg.MsgCheck{}, "shareledger/bank/MsgCheck", nil)
 	cdc.RegisterConcrete(msg.MsgLoad{}, "shareledger/bank/MsgLoad", nil)
 	cdc.RegisterConcrete(msg.MsgBurn{}, "shareledger/bank/MsgBurn", nil)
+	cdc.RegisterConcrete(msg.MsgSetIssuer{}, "shareledger/bank/MsgSetIssuer", nil)
+	cdc.RegisterConcrete(msg.MsgRemoveIssuer{}, "shareledger/bank/MsgRemoveIssuer", nil)
 	return cdc
 }
@@ -0,0 +1,60 @@
+package bank
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// GenesisState - issuers registered at genesis, the supply is what genesis accounts hold
+type GenesisState struct {
+	Issuers []types.Issuer `json:"issuers"`
+}
+
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) error {
+	for _, issuer := range data.Issuers {
+		if err := issuer.Validate(); err != nil {
+			return err
+		}
+
+		// nothing minted yet unless the genesis says otherwise
+		if len(issuer.Minted) == 0 {
+			issuer.Minted = types.NewDefaultCoins()
+		}
+
+		k.setIssuer(ctx, issuer)
+	}
+
+	// genesis accounts are loaded first, they hold the whole supply
+	k.setSupply(ctx, k.SumAccounts(ctx))
+	return nil
+}
+
+// GenerateGenesis - reserve accounts are the issuers, with the default limits
+func GenerateGenesis() GenesisState {
+	var issuers []types.Issuer
+
+	for _, reserve := range constants.RESERVE_ACCOUNTS {
+		addr, err := hex.DecodeString(reserve)
+		if err != nil {
+			panic(err)
+		}
+
+		var limits []types.MintLimit
+
+		for _, denom := range constants.ALL_DENOMS {
+			limits = append(limits, types.NewMintLimit(
+				types.NewCoin(denom, constants.ISSUER_DEFAULT_CAP),
+				types.NewCoin(denom, constants.ISSUER_DEFAULT_WINDOW_LIMIT),
+				constants.ISSUER_DEFAULT_WINDOW,
+			))
+		}
+
+		issuers = append(issuers, types.NewIssuer(sdk.AccAddress(addr), limits))
+	}
+
+	return GenesisState{Issuers: issuers}
+}
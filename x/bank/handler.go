@@ -1,19 +1,24 @@
 package bank
 
 import (
+	"bytes"
+	"fmt"
 	"reflect"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/bank/handlers"
 	"github.com/sharering/shareledger/x/bank/messages"
+	"github.com/sharering/shareledger/x/bank/tags"
 
 	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
 )
 
-func NewHandler(am auth.AccountMapper) sdkTypes.Handler {
+func NewHandler(k Keeper) sdkTypes.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdkTypes.Result {
 		constants.LOGGER.Info(
 			"Msg for Bank Module",
@@ -25,14 +30,91 @@ func NewHandler(am auth.AccountMapper) sdkTypes.Handler {
 		// case messages.MsgCheck:
 		// return handlers.HandleMsgCheck(am)(ctx, msg)
 		case messages.MsgLoad:
-			return handlers.HandleMsgLoad(am)(ctx, msg)
+			return sdkTypes.NewResult(handleLoad(ctx, k, msg))
 		case messages.MsgSend:
-			return handlers.HandleMsgSend(am)(ctx, msg)
+			return handlers.HandleMsgSend(k.am)(ctx, msg)
 		case messages.MsgBurn:
-			return handlers.HandleMsgBurn(am)(ctx, msg)
+			return sdkTypes.NewResult(handleBurn(ctx, k, msg))
+		case messages.MsgSetIssuer:
+			return sdkTypes.NewResult(handleSetIssuer(ctx, k, msg))
+		case messages.MsgRemoveIssuer:
+			return sdkTypes.NewResult(handleRemoveIssuer(ctx, k, msg))
 		default:
 			errMsg := "Unrecognized bank Msg type" + reflect.TypeOf(msg).Name()
 			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
 		}
 	}
 }
+
+// Only registered issuers mint, within their limits
+func handleLoad(ctx sdk.Context, k Keeper, msg messages.MsgLoad) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	entry, err := k.Mint(ctx, signer.GetAddress(), msg.Account, msg.Amount)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: entry.String(),
+		Tags: msg.Tags().
+			AppendTag(tags.Issuer, signer.GetAddress().String()).
+			AppendTag(tags.SupplySeq, strconv.FormatInt(entry.Seq, 10)),
+	}
+}
+
+func handleBurn(ctx sdk.Context, k Keeper, msg messages.MsgBurn) sdk.Result {
+	signer := auth.GetSigner(ctx)
+
+	// Only reserve is allowed to execute this function
+	if !utils.IsValidReserve(signer.GetAddress()) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.RES_RESERVE_ONLY)).Result()
+	}
+
+	if !bytes.Equal(signer.GetAddress(), msg.Account) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.RES_OWN_ACCOUNT, msg.Account, signer.GetAddress())).Result()
+	}
+
+	entry, err := k.Burn(ctx, signer.GetAddress(), msg.Account, msg.Amount)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  entry.String(),
+		Tags: msg.Tags().AppendTag(tags.SupplySeq, strconv.FormatInt(entry.Seq, 10)),
+	}
+}
+
+// Issuers are registered and removed by the reserve
+func handleSetIssuer(ctx sdk.Context, k Keeper, msg messages.MsgSetIssuer) sdk.Result {
+	if !utils.IsValidReserve(auth.GetSigner(ctx).GetAddress()) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.RES_RESERVE_ONLY)).Result()
+	}
+
+	issuer, err := k.SetIssuer(ctx, msg.Issuer, msg.Limits)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  issuer.String(),
+		Tags: msg.Tags(),
+	}
+}
+
+func handleRemoveIssuer(ctx sdk.Context, k Keeper, msg messages.MsgRemoveIssuer) sdk.Result {
+	if !utils.IsValidReserve(auth.GetSigner(ctx).GetAddress()) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.RES_RESERVE_ONLY)).Result()
+	}
+
+	issuer, err := k.RemoveIssuer(ctx, msg.Issuer)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  issuer.String(),
+		Tags: msg.Tags(),
+	}
+}
@@ -6,7 +6,6 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/types"
-	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
 	Err "github.com/sharering/shareledger/x/bank/error"
 	"github.com/sharering/shareledger/x/bank/messages"
@@ -34,6 +33,11 @@ func HandleMsgSend(am auth.AccountMapper) sdkTypes.Handler {
 		// Get signer from signatures
 		signer := auth.GetSigner(ctx)
 
+		// An employee sending company coins spends from its grant
+		if err := am.SpendGrant(ctx, sendMsg.Amount); err != nil {
+			return sdkTypes.NewResult(err.Result())
+		}
+
 		// Debit from the sender.
 		var resF sdk.Result
 		var resT sdk.Result
@@ -51,17 +55,12 @@ func HandleMsgSend(am auth.AccountMapper) sdkTypes.Handler {
 		res := fmt.Sprintf("{\"from\":%v, \"to\":%v}", resF.Log, resT.Log)
 		// Return a success (Code 0).
 		// Add list of key-value pair descriptors ("tags").
-		fee, denom := utils.GetMsgFee(msg)
-
-		return sdkTypes.Result{
-			Result: sdk.Result{
-				Log:       res,
-				Data:      append(resF.Data, resT.Data...),
-				Tags:      sendMsg.Tags().AppendTag(tags.FromAddress, signer.GetAddress().String()),
-			},
-			FeeAmount: fee,
-			FeeDenom:  denom,
-		}
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(sdk.Result{
+			Log:  res,
+			Data: append(resF.Data, resT.Data...),
+			Tags: sendMsg.Tags().AppendTag(tags.FromAddress, signer.GetAddress().String()),
+		})
 	}
 }
 
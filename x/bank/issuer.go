@@ -0,0 +1,183 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// GetIssuer returns the issuer registered at *addr*
+func (k Keeper) GetIssuer(ctx sdk.Context, addr sdk.AccAddress) (issuer types.Issuer, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetIssuerKey(addr))
+	if bz == nil {
+		return issuer, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &issuer)
+	return issuer, true
+}
+
+// GetIssuers returns every registered issuer
+func (k Keeper) GetIssuers(ctx sdk.Context) []types.Issuer {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, IssuerKey)
+	defer iterator.Close()
+
+	issuers := []types.Issuer{}
+
+	for ; iterator.Valid(); iterator.Next() {
+		var issuer types.Issuer
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &issuer)
+		issuers = append(issuers, issuer)
+	}
+	return issuers
+}
+
+func (k Keeper) setIssuer(ctx sdk.Context, issuer types.Issuer) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetIssuerKey(issuer.Address), k.cdc.MustMarshalBinaryLengthPrefixed(issuer))
+}
+
+// SetIssuer - register *addr* as an issuer or replace its limits.
+// What an issuer already minted still counts against its new caps.
+func (k Keeper) SetIssuer(ctx sdk.Context, addr sdk.AccAddress, limits []types.MintLimit) (types.Issuer, error) {
+	issuer, found := k.GetIssuer(ctx, addr)
+	if !found {
+		issuer = types.NewIssuer(addr, limits)
+	}
+	issuer.Limits = limits
+
+	if err := issuer.Validate(); err != nil {
+		return types.Issuer{}, err
+	}
+
+	k.setIssuer(ctx, issuer)
+	return issuer, nil
+}
+
+// RemoveIssuer - *addr* can no longer mint
+func (k Keeper) RemoveIssuer(ctx sdk.Context, addr sdk.AccAddress) (types.Issuer, error) {
+	issuer, found := k.GetIssuer(ctx, addr)
+	if !found {
+		return types.Issuer{}, fmt.Errorf(constants.ISSUER_NOT_FOUND, utils.ByteToString(addr))
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetIssuerKey(addr))
+	return issuer, nil
+}
+
+// Mint - *issuer* credits *amt* to *account*, within its cap and the limit of its rolling window
+func (k Keeper) Mint(ctx sdk.Context, issuerAddr sdk.AccAddress, account sdk.AccAddress, amt types.Coin) (types.SupplyEntry, error) {
+	issuer, found := k.GetIssuer(ctx, issuerAddr)
+	if !found {
+		return types.SupplyEntry{}, fmt.Errorf(constants.ISSUER_NOT_FOUND, utils.ByteToString(issuerAddr))
+	}
+
+	limit, ok := issuer.LimitOf(amt.Denom)
+	if !ok {
+		return types.SupplyEntry{}, fmt.Errorf(constants.ISSUER_DENOM_NOT_ALLOWED,
+			utils.ByteToString(issuerAddr),
+			amt.Denom)
+	}
+
+	minted := issuer.Minted.GetCoin(amt.Denom)
+	if minted.Plus(amt).GT(limit.Cap) {
+		return types.SupplyEntry{}, fmt.Errorf(constants.ISSUER_CAP_EXCEEDED,
+			amt.String(),
+			utils.ByteToString(issuerAddr),
+			minted.String(),
+			limit.Cap.String())
+	}
+
+	now := ctx.BlockHeader().Time.Unix()
+
+	windowMinted := k.mintedInWindow(ctx, issuerAddr, amt.Denom, now, limit.Window)
+	if windowMinted.Plus(amt).GT(limit.WindowLimit) {
+		return types.SupplyEntry{}, fmt.Errorf(constants.ISSUER_WINDOW_EXCEEDED,
+			amt.String(),
+			utils.ByteToString(issuerAddr),
+			windowMinted.String(),
+			limit.WindowLimit.String(),
+			limit.Window)
+	}
+
+	if _, err := addCoin(ctx, k.am, account, amt); err != nil {
+		return types.SupplyEntry{}, err
+	}
+
+	k.AddSupply(ctx, amt)
+
+	issuer.Minted = issuer.Minted.Plus(amt)
+	k.setIssuer(ctx, issuer)
+
+	entry := k.recordSupply(ctx, constants.SUPPLY_MINT, issuerAddr, account, amt)
+
+	k.pruneMintWindow(ctx, issuer, now)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetMintWindowKey(issuerAddr, now, entry.Seq), k.cdc.MustMarshalBinaryLengthPrefixed(amt))
+
+	return entry, nil
+}
+
+// Burn - *signer* destroys *amt* held by *account*
+func (k Keeper) Burn(ctx sdk.Context, signer sdk.AccAddress, account sdk.AccAddress, amt types.Coin) (types.SupplyEntry, error) {
+	if _, err := subtractCoin(ctx, k.am, account, amt); err != nil {
+		return types.SupplyEntry{}, err
+	}
+
+	k.SubtractSupply(ctx, amt)
+
+	return k.recordSupply(ctx, constants.SUPPLY_BURN, signer, account, amt), nil
+}
+
+// mintedInWindow - what an issuer minted of *denom* in the *window* seconds up to *now*
+func (k Keeper) mintedInWindow(ctx sdk.Context, addr sdk.AccAddress, denom string, now int64, window int64) types.Coin {
+	store := ctx.KVStore(k.storeKey)
+
+	minted := types.NewCoin(denom, 0)
+
+	iterator := store.Iterator(GetMintWindowTimeKey(addr, now-window+1), GetMintWindowTimeKey(addr, now+1))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var amt types.Coin
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &amt)
+
+		if amt.HasDenom(denom) {
+			minted = minted.Plus(amt)
+		}
+	}
+	return minted
+}
+
+// pruneMintWindow - forget the mints of an issuer older than its longest window
+func (k Keeper) pruneMintWindow(ctx sdk.Context, issuer types.Issuer, now int64) {
+	store := ctx.KVStore(k.storeKey)
+
+	var longest int64
+	for _, l := range issuer.Limits {
+		if l.Window > longest {
+			longest = l.Window
+		}
+	}
+
+	// collect first, the store cannot be modified while iterating
+	var expired [][]byte
+
+	iterator := store.Iterator(GetMintWindowPrefix(issuer.Address), GetMintWindowTimeKey(issuer.Address, now-longest+1))
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range expired {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,44 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/sharering/shareledger/types"
+)
+
+func TestIssuerValidate(t *testing.T) {
+	pub, _ := types.GenerateKeyPair()
+
+	limit := types.NewMintLimit(types.NewCoin("SHRP", 1000), types.NewCoin("SHRP", 100), 3600)
+
+	issuer := types.NewIssuer(pub.Address(), []types.MintLimit{limit})
+	if err := issuer.Validate(); err != nil {
+		t.Fatalf("Issuer should be valid. %s", err)
+	}
+
+	if l, ok := issuer.LimitOf("SHRP"); !ok || !l.Cap.Equal(limit.Cap) {
+		t.Error("Issuer should mint SHRP within its cap.")
+	}
+
+	if _, ok := issuer.LimitOf("SHR"); ok {
+		t.Error("Issuer should not mint SHR.")
+	}
+
+	issuer.Limits = append(issuer.Limits, limit)
+	if issuer.Validate() == nil {
+		t.Error("Two limits for the same denom should be rejected.")
+	}
+
+	invalid := []types.MintLimit{
+		types.NewMintLimit(types.NewCoin("SHRP", 100), types.NewCoin("SHRP", 1000), 3600), // window limit above cap
+		types.NewMintLimit(types.NewCoin("SHRP", 1000), types.NewCoin("SHR", 100), 3600),  // different denoms
+		types.NewMintLimit(types.NewCoin("SHRP", 1000), types.NewCoin("SHRP", 100), 0),    // no window
+		types.NewMintLimit(types.NewCoin("ABC", 1000), types.NewCoin("ABC", 100), 3600),   // unknown denom
+	}
+
+	for _, l := range invalid {
+		if l.Validate() == nil {
+			t.Errorf("Limit %s should be invalid.", l.String())
+		}
+	}
+}
@@ -6,14 +6,21 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/sharering/shareledger/types"
 	"github.com/sharering/shareledger/x/auth"
+	"github.com/tendermint/go-amino"
 )
 
 type Keeper struct {
-	am auth.AccountMapper
+	am       auth.AccountMapper
+	storeKey sdk.StoreKey // issuers and the supply ledger
+	cdc      *amino.Codec
 }
 
-func NewKeeper(_am auth.AccountMapper) Keeper {
-	return Keeper{am: _am}
+func NewKeeper(_am auth.AccountMapper, key sdk.StoreKey, cdc *amino.Codec) Keeper {
+	return Keeper{
+		am:       _am,
+		storeKey: key,
+		cdc:      cdc,
+	}
 }
 
 
@@ -61,6 +68,26 @@ func (k Keeper) SubtractCoin(
 	return subtractCoin(ctx, k.am, addr, amt)
 }
 
+// SpendGrant - charge *amt* to the grant the transaction was signed under, if any
+func (k Keeper) SpendGrant(
+	ctx sdk.Context,
+	amt types.Coin,
+) sdk.Error {
+
+	return k.am.SpendGrant(ctx, amt)
+}
+
+// ChargeGrant - charge *amt* to the grant *granter* gave to *grantee*
+func (k Keeper) ChargeGrant(
+	ctx sdk.Context,
+	granter sdk.AccAddress,
+	grantee sdk.AccAddress,
+	amt types.Coin,
+) sdk.Error {
+
+	return k.am.ChargeGrant(ctx, granter, grantee, amt)
+}
+
 func (k Keeper) AddCoin(
 	ctx sdk.Context,
 	addr sdk.AccAddress,
@@ -162,8 +189,9 @@ func getCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) types
 
 	acc := am.GetAccount(ctx, addr)
 
+	// an account without any record yet holds zero of every denom
 	if acc == nil {
-		return types.Coins{}
+		return types.NewDefaultCoins()
 	}
 
 	return acc.GetCoins()
@@ -0,0 +1,124 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+var (
+	testIssuer  = sdk.AccAddress([]byte("issuer______________"))
+	testAccount = sdk.AccAddress([]byte("account_____________"))
+)
+
+func setupTestKeeper(t *testing.T) (sdk.Context, Keeper, auth.AccountMapper) {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000000, 0)}, false, log.NewNopLogger())
+	return ctx, NewKeeper(am, bankKey, cdc), am
+}
+
+func TestSupplyUpgrade(t *testing.T) {
+	ctx, k, am := setupTestKeeper(t)
+
+	// accounts of a chain which did not track its supply
+	acc := auth.NewSHRAccountWithAddress(testAccount)
+	acc.SetCoins(types.Coins{types.NewCoin("SHRP", 70), types.NewCoin("SHR", 5)})
+	am.SetAccount(ctx, acc)
+
+	if k.HasSupply(ctx) {
+		t.Fatalf("No supply should be stored before the upgrade.")
+	}
+
+	BeginBlocker(ctx, k)
+
+	if err := k.CheckSupply(ctx); err != nil {
+		t.Errorf("Supply should be what accounts hold once upgraded. %s", err)
+	}
+
+	// the supply is only set once, later blocks keep tracking it
+	k.AddSupply(ctx, types.NewCoin("SHRP", 1))
+	BeginBlocker(ctx, k)
+
+	if !k.GetSupply(ctx).GetCoin("SHRP").Equal(types.NewCoin("SHRP", 71)) {
+		t.Errorf("Supply should not be reset, got %s.", k.GetSupply(ctx).String())
+	}
+}
+
+func TestMintBurnRoundTrip(t *testing.T) {
+	ctx, k, _ := setupTestKeeper(t)
+	BeginBlocker(ctx, k)
+
+	limits := []types.MintLimit{types.NewMintLimit(
+		types.NewCoin("SHRP", 1000),
+		types.NewCoin("SHRP", 500),
+		constants.SECONDS_PER_DAY,
+	)}
+	if _, err := k.SetIssuer(ctx, testIssuer, limits); err != nil {
+		t.Fatalf("Registering the issuer failed. %s", err)
+	}
+
+	if _, err := k.Mint(ctx, testIssuer, testAccount, types.NewCoin("SHRP", 400)); err != nil {
+		t.Fatalf("Minting failed. %s", err)
+	}
+
+	// over the limit of the window
+	if _, err := k.Mint(ctx, testIssuer, testAccount, types.NewCoin("SHRP", 101)); err == nil {
+		t.Errorf("Minting over the window limit should fail.")
+	}
+
+	if _, err := k.Burn(ctx, testAccount, testAccount, types.NewCoin("SHRP", 150)); err != nil {
+		t.Fatalf("Burning failed. %s", err)
+	}
+
+	if _, err := k.Burn(ctx, testAccount, testAccount, types.NewCoin("SHRP", 251)); err == nil {
+		t.Errorf("Burning more than the account holds should fail.")
+	}
+
+	if !k.GetSupply(ctx).GetCoin("SHRP").Equal(types.NewCoin("SHRP", 250)) {
+		t.Errorf("Supply should be 250SHRP, got %s.", k.GetSupply(ctx).String())
+	}
+
+	if err := k.CheckSupply(ctx); err != nil {
+		t.Errorf("Supply invariant broken. %s", err)
+	}
+
+	// a mint and a burn are recorded
+	if seq := k.GetSupplySeq(ctx); seq != 2 {
+		t.Errorf("Supply ledger should have 2 entries, got %d.", seq)
+	}
+
+	entry, found := k.GetSupplyEntry(ctx, 2)
+	if !found || entry.Kind != constants.SUPPLY_BURN || !entry.Amount.Equal(types.NewCoin("SHRP", 150)) {
+		t.Errorf("Second entry should burn 150SHRP, got %v.", entry)
+	}
+}
@@ -0,0 +1,51 @@
+package bank
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	IssuerKey      = []byte{0x01} // prefix for each key to an issuer
+	SupplyEntryKey = []byte{0x02} // prefix for each key to an entry of the supply ledger, by sequence
+	MintWindowKey  = []byte{0x03} // prefix for each key to a recent mint of an issuer, by time and sequence
+	SupplySeqKey   = []byte{0x04} // key for the sequence of the last supply entry
+	SupplyKey      = []byte{0x05} // key for the total supply held by accounts
+)
+
+func int64ToBytes(i int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(i))
+	return bz
+}
+
+// gets the key for an issuer
+// VALUE: types.Issuer
+func GetIssuerKey(addr sdk.AccAddress) []byte {
+	return append(IssuerKey, addr.Bytes()...)
+}
+
+// gets the key for an entry of the supply ledger
+// VALUE: types.SupplyEntry
+func GetSupplyEntryKey(seq int64) []byte {
+	return append(SupplyEntryKey, int64ToBytes(seq)...)
+}
+
+// gets the prefix for the recent mints of an issuer
+func GetMintWindowPrefix(addr sdk.AccAddress) []byte {
+	return append(MintWindowKey, addr.Bytes()...)
+}
+
+// gets the key for a mint of an issuer at *time*
+// VALUE: types.Coin
+func GetMintWindowKey(addr sdk.AccAddress, time int64, seq int64) []byte {
+	return append(GetMintWindowTimeKey(addr, time), int64ToBytes(seq)...)
+}
+
+// gets the key for the first mint of an issuer at or after *time*
+func GetMintWindowTimeKey(addr sdk.AccAddress, time int64) []byte {
+	return append(GetMintWindowPrefix(addr), int64ToBytes(time)...)
+}
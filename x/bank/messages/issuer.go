@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/bank/tags"
+)
+
+//----------------------------------------------------------------
+// Msg
+
+var _ sdk.Msg = MsgSetIssuer{}
+
+// MsgSetIssuer - register an issuer or replace its mint limits
+type MsgSetIssuer struct {
+	Issuer sdk.AccAddress    `json:"issuer"`
+	Limits []types.MintLimit `json:"limits"`
+}
+
+func NewMsgSetIssuer(issuer sdk.AccAddress, limits []types.MintLimit) MsgSetIssuer {
+	return MsgSetIssuer{issuer, limits}
+}
+
+func (msg MsgSetIssuer) Route() string { return constants.MESSAGE_BANK }
+
+// Implement Msg
+func (msg MsgSetIssuer) Type() string { return constants.MESSAGE_BANK }
+
+// Implement Msg. Ensure the address is good and every limit is valid
+func (msg MsgSetIssuer) ValidateBasic() sdk.Error {
+	if len(msg.Issuer) == 0 {
+		return sdk.ErrInvalidAddress("Issuer address is empty")
+	}
+
+	if len(msg.Limits) == 0 {
+		return sdk.ErrUnknownRequest(constants.ISSUER_NO_LIMITS)
+	}
+
+	if err := types.NewIssuer(msg.Issuer, msg.Limits).Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+func (msg MsgSetIssuer) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (msg MsgSetIssuer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgSetIssuer) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Issuer, msg.Issuer.String()).
+		AppendTag(tags.Event, tags.IssuerSet)
+}
+
+//----------------------------------------------------------------
+// Msg
+
+var _ sdk.Msg = MsgRemoveIssuer{}
+
+// MsgRemoveIssuer - an issuer can no longer mint
+type MsgRemoveIssuer struct {
+	Issuer sdk.AccAddress `json:"issuer"`
+}
+
+func NewMsgRemoveIssuer(issuer sdk.AccAddress) MsgRemoveIssuer {
+	return MsgRemoveIssuer{issuer}
+}
+
+func (msg MsgRemoveIssuer) Route() string { return constants.MESSAGE_BANK }
+
+// Implement Msg
+func (msg MsgRemoveIssuer) Type() string { return constants.MESSAGE_BANK }
+
+// Implement Msg. Ensure the address is good
+func (msg MsgRemoveIssuer) ValidateBasic() sdk.Error {
+	if len(msg.Issuer) == 0 {
+		return sdk.ErrInvalidAddress("Issuer address is empty")
+	}
+	return nil
+}
+
+func (msg MsgRemoveIssuer) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (msg MsgRemoveIssuer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgRemoveIssuer) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Issuer, msg.Issuer.String()).
+		AppendTag(tags.Event, tags.IssuerRemoved)
+}
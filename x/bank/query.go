@@ -7,19 +7,29 @@ import (
 	amino "github.com/tendermint/go-amino"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/sharering/shareledger/constants"
 	"github.com/sharering/shareledger/x/auth"
 )
 
 // query endpoints supported by auth querier
 const (
 	QueryBalance = "balance"
+	QueryIssuers = "issuers"
+	QueryLedger  = "ledger"
+	QuerySupply  = "supply"
 )
 
-func NewQuerier(am auth.AccountMapper, cdc *amino.Codec) sdk.Querier {
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
 		case QueryBalance:
-			return queryBalance(ctx, cdc, req, am)
+			return queryBalance(ctx, cdc, req, k.am)
+		case QueryIssuers:
+			return queryIssuers(ctx, cdc, k)
+		case QueryLedger:
+			return queryLedger(ctx, cdc, req, k)
+		case QuerySupply:
+			return querySupply(ctx, cdc, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
 		}
@@ -30,6 +40,13 @@ type QueryBalanceParams struct {
 	Address sdk.AccAddress
 }
 
+// defines the params for the following queries:
+// - 'custom/bank/ledger'
+type QueryLedgerParams struct {
+	Start int64 // sequence of the first entry, entries start at 1
+	Limit int64 // at most SUPPLY_LEDGER_PAGE
+}
+
 func queryBalance(
 	ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, am auth.AccountMapper,
 ) (res []byte, err sdk.Error) {
@@ -52,3 +69,38 @@ func queryBalance(
 
 	return res, nil
 }
+
+func queryIssuers(ctx sdk.Context, cdc *amino.Codec, k Keeper) (res []byte, err sdk.Error) {
+	res, errRes := cdc.MarshalJSON(k.GetIssuers(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Issuer"))
+	}
+	return res, nil
+}
+
+func queryLedger(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryLedgerParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryLedgerParams"))
+	}
+
+	if params.Limit <= 0 || params.Limit > constants.SUPPLY_LEDGER_PAGE {
+		params.Limit = constants.SUPPLY_LEDGER_PAGE
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetSupplyLedger(ctx, params.Start, params.Limit))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.SupplyEntry"))
+	}
+	return res, nil
+}
+
+func querySupply(ctx sdk.Context, cdc *amino.Codec, k Keeper) (res []byte, err sdk.Error) {
+	res, errRes := cdc.MarshalJSON(k.GetSupply(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "types.Coins"))
+	}
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+// GetSupply returns the total of each denom held by accounts.
+// Staked coins and charged fees leave the supply until they are paid back to an account.
+func (k Keeper) GetSupply(ctx sdk.Context) types.Coins {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(SupplyKey)
+	if bz == nil {
+		return types.NewDefaultCoins()
+	}
+
+	var supply types.Coins
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &supply)
+	return supply
+}
+
+// HasSupply - whether the supply was set, at genesis or by the upgrade to supply tracking
+func (k Keeper) HasSupply(ctx sdk.Context) bool {
+	return ctx.KVStore(k.storeKey).Has(SupplyKey)
+}
+
+func (k Keeper) setSupply(ctx sdk.Context, supply types.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(SupplyKey, k.cdc.MustMarshalBinaryLengthPrefixed(supply))
+}
+
+// AddSupply - *amt* was credited to an account out of no other account, e.g. a block reward
+func (k Keeper) AddSupply(ctx sdk.Context, amt types.Coin) {
+	supply := k.GetSupply(ctx)
+	k.setSupply(ctx, supply.Plus(amt))
+}
+
+// SubtractSupply - *amt* was debited from an account into no other account, e.g. a fee
+func (k Keeper) SubtractSupply(ctx sdk.Context, amt types.Coin) {
+	supply := k.GetSupply(ctx)
+	k.setSupply(ctx, supply.Minus(amt))
+}
+
+// SumAccounts returns the total of each denom held by the accounts of the auth store
+func (k Keeper) SumAccounts(ctx sdk.Context) types.Coins {
+	total := types.NewDefaultCoins()
+
+	k.am.IterateAccounts(ctx, func(acc auth.BaseAccount) bool {
+		total = total.PlusMany(acc.GetCoins())
+		return false
+	})
+	return total
+}
+
+// CheckSupply - invariant, the total supply is what accounts hold
+func (k Keeper) CheckSupply(ctx sdk.Context) error {
+	supply := k.GetSupply(ctx)
+	held := k.SumAccounts(ctx)
+
+	for _, denom := range constants.ALL_DENOMS {
+		if !supply.GetCoin(denom).Equal(held.GetCoin(denom)) {
+			return fmt.Errorf(constants.SUPPLY_MISMATCH, supply.String(), held.String())
+		}
+	}
+	return nil
+}
+
+// GetSupplyEntry returns the entry of the supply ledger at *seq*
+func (k Keeper) GetSupplyEntry(ctx sdk.Context, seq int64) (entry types.SupplyEntry, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetSupplyEntryKey(seq))
+	if bz == nil {
+		return entry, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &entry)
+	return entry, true
+}
+
+// GetSupplyLedger returns at most *limit* entries of the supply ledger, starting at *start*
+func (k Keeper) GetSupplyLedger(ctx sdk.Context, start int64, limit int64) []types.SupplyEntry {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(GetSupplyEntryKey(start), sdk.PrefixEndBytes(SupplyEntryKey))
+	defer iterator.Close()
+
+	entries := []types.SupplyEntry{}
+
+	for ; iterator.Valid() && int64(len(entries)) < limit; iterator.Next() {
+		var entry types.SupplyEntry
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// GetSupplySeq returns the sequence of the last entry of the supply ledger, 0 when empty
+func (k Keeper) GetSupplySeq(ctx sdk.Context) (seq int64) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(SupplySeqKey)
+	if bz == nil {
+		return 0
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &seq)
+	return seq
+}
+
+// recordSupply appends a mint or a burn to the supply ledger
+func (k Keeper) recordSupply(
+	ctx sdk.Context,
+	kind string,
+	signer sdk.AccAddress,
+	account sdk.AccAddress,
+	amt types.Coin,
+) types.SupplyEntry {
+	store := ctx.KVStore(k.storeKey)
+
+	entry := types.SupplyEntry{
+		Seq:     k.GetSupplySeq(ctx) + 1,
+		Kind:    kind,
+		Signer:  signer,
+		Account: account,
+		Amount:  amt,
+		Height:  ctx.BlockHeight(),
+		Time:    ctx.BlockHeader().Time.Unix(),
+	}
+
+	store.Set(GetSupplyEntryKey(entry.Seq), k.cdc.MustMarshalBinaryLengthPrefixed(entry))
+	store.Set(SupplySeqKey, k.cdc.MustMarshalBinaryLengthPrefixed(entry.Seq))
+	return entry
+}
@@ -7,8 +7,12 @@ var (
 	Amount         = "Amount"
 	Event          = "Event"
 	AccountAddress = "AccountAddress"
+	Issuer         = "Issuer"
+	SupplySeq      = "SupplySeq"
 
 	//Value -  []byte
-	Transfered = "Transfered" //Transfer event fromAddress To Address
-	Credit     = "Credit"     //event for credit
+	Transfered    = "Transfered"    //Transfer event fromAddress To Address
+	Credit        = "Credit"        //event for credit
+	IssuerSet     = "IssuerSet"     //issuer registered or its limits replaced
+	IssuerRemoved = "IssuerRemoved" //issuer can no longer mint
 )
@@ -0,0 +1,46 @@
+package booking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// EndBlocker - returns deposits whose claim window is closed, releases unanswered
+// booking requests, charges subscriptions and checks booking invariants.
+func EndBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	// A chain upgrading to full length IDs migrates at its first block on this version
+	if ctx.BlockHeight() >= constants.BOOKING_ID_MIGRATION_HEIGHT && !k.IsMigrated(ctx) {
+		k.MigrateLegacyBookings(ctx)
+	}
+
+	resTags := k.ReturnExpiredDeposits(ctx)
+	resTags = resTags.AppendTags(k.ExpireRequests(ctx))
+	resTags = resTags.AppendTags(k.ChargeSubscriptions(ctx))
+
+	checkEscrow(ctx, k)
+
+	return resTags
+}
+
+// checkEscrow - checks the escrow every ESCROW_INVARIANT_PERIOD blocks.
+// Summing open bookings is costly, a mismatch halts the chain only if ESCROW_INVARIANT_HALT is set.
+func checkEscrow(ctx sdk.Context, k Keeper) {
+	if constants.ESCROW_INVARIANT_PERIOD <= 0 || ctx.BlockHeight()%constants.ESCROW_INVARIANT_PERIOD != 0 {
+		return
+	}
+
+	err := EscrowInvariant(ctx, k)
+	if err == nil {
+		return
+	}
+
+	if constants.ESCROW_INVARIANT_HALT {
+		panic(err)
+	}
+
+	constants.LOGGER.Error("Escrow invariant broken",
+		"height", ctx.BlockHeight(),
+		"err", err.Error(),
+	)
+}
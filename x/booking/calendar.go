@@ -0,0 +1,97 @@
+package booking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// GetCalendar returns the booked slots of an asset ordered by start time.
+// Only slots which are not yet completed are part of the calendar.
+func (k Keeper) GetCalendar(ctx sdk.Context, uuid string) (bookings []types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetCalendarPrefix(uuid))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var booking types.Booking
+
+		err := utils.Retrieve(store, iterator.Value(), &booking)
+		if err != nil {
+			panic(err)
+		}
+
+		bookings = append(bookings, booking)
+	}
+	return bookings
+}
+
+// HasActiveBooking - whether any booked slot of an asset is not yet completed or cancelled,
+// the asset is rented by an active subscription or held by a legacy booking
+func (k Keeper) HasActiveBooking(ctx sdk.Context, uuid string) bool {
+	store := ctx.KVStore(k.bookingKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetCalendarPrefix(uuid))
+	defer iterator.Close()
+
+	if iterator.Valid() {
+		return true
+	}
+
+	if _, subscribed := k.getActiveSubscription(ctx, uuid); subscribed {
+		return true
+	}
+
+	return k.HasLegacyBooking(ctx, uuid)
+}
+
+// HasLegacyBooking - whether a legacy booking holds the asset. Legacy bookings have no
+// calendar slot, they hold their asset by clearing its status until they end.
+func (k Keeper) HasLegacyBooking(ctx sdk.Context, uuid string) bool {
+	asset, found := k.GetAsset(ctx, uuid)
+	if !found || asset.Status {
+		return false
+	}
+
+	for _, booking := range k.GetBookingsByAsset(ctx, uuid, constants.BOOKING_FILTER_ACTIVE) {
+		if booking.IsLegacy() {
+			return true
+		}
+	}
+	return false
+}
+
+// getOverlappingBooking returns the first booked slot of an asset sharing time with [start, end)
+func (k Keeper) getOverlappingBooking(
+	ctx sdk.Context,
+	uuid string,
+	start int64,
+	end int64,
+) (
+	types.Booking, bool,
+) {
+	for _, booking := range k.GetCalendar(ctx, uuid) {
+		// slots are ordered by start time, none of the remaining ones can overlap
+		if booking.Start >= end {
+			break
+		}
+
+		if booking.Overlaps(start, end) {
+			return booking, true
+		}
+	}
+	return types.Booking{}, false
+}
+
+// setCalendarSlot reserves the slot of a booking in the calendar of its asset
+func (k Keeper) setCalendarSlot(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetCalendarKey(booking.UUID, booking.Start, booking.BookingID), []byte(booking.BookingID))
+}
+
+// removeCalendarSlot releases the slot of a booking from the calendar of its asset
+func (k Keeper) removeCalendarSlot(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetCalendarKey(booking.UUID, booking.Start, booking.BookingID))
+}
@@ -0,0 +1,139 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// CheckIn - renter takes over the asset of a confirmed booking once it started, attested by the asset device
+func (k Keeper) CheckIn(ctx sdk.Context, msg msg.MsgCheckIn) (types.Booking, error) {
+
+	booking, err := k.getAttestedBooking(ctx, msg.BookingID, msg.Height, msg.DeviceSignBytes(), msg.DeviceSignature)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	// the slot before the booking may belong to another renter
+	if ctx.BlockHeader().Time.Unix() < booking.Start {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CHECK_IN_EARLY,
+			booking.BookingID,
+			booking.Start)
+	}
+
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_CHECKED_IN); err != nil {
+		return types.Booking{}, err
+	}
+
+	booking.CheckInHeight = msg.Height
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// CheckOut - renter hands the asset of a checked in booking back, attested by the asset device.
+// The booking stays checked in until it is completed.
+func (k Keeper) CheckOut(ctx sdk.Context, msg msg.MsgCheckOut) (types.Booking, error) {
+
+	booking, err := k.getAttestedBooking(ctx, msg.BookingID, msg.Height, msg.DeviceSignBytes(), msg.DeviceSignature)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	if booking.GetStatus() != constants.BOOKING_STATUS_CHECKED_IN {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_CHECKED_IN,
+			booking.BookingID)
+	}
+
+	if booking.CheckOutHeight != 0 {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ALREADY_CHECKED_OUT,
+			booking.BookingID)
+	}
+
+	booking.CheckOutHeight = msg.Height
+
+	if err := k.setBooking(ctx, booking); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// getAttestedBooking returns a booking signed for by its renter once the asset device signature over *signBytes* at block *height* is verified
+func (k Keeper) getAttestedBooking(
+	ctx sdk.Context,
+	bookingID string,
+	height int64,
+	signBytes []byte,
+	sig types.SignatureSecp256k1,
+) (
+	types.Booking, error,
+) {
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			bookingID,
+			constants.STORE_BOOKING)
+	}
+
+	// renter deduced from signature
+	renter := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(renter, booking.Renter) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_MISMATCH_RENTER,
+			utils.ByteToString(booking.Renter),
+			utils.ByteToString(renter))
+	}
+
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
+			constants.STORE_ASSET)
+	}
+
+	if !asset.HasDevice() {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NO_DEVICE,
+			asset.UUID)
+	}
+
+	// a recent attestation proves the device saw the handover now, not before
+	current := ctx.BlockHeight()
+	if height > current || current-height > constants.BOOKING_ATTESTATION_WINDOW {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ATTESTATION_HEIGHT,
+			height,
+			constants.BOOKING_ATTESTATION_WINDOW,
+			current)
+	}
+
+	if !asset.DevicePubKey.VerifyBytes(signBytes, sig) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INVALID_ATTESTATION,
+			asset.UUID,
+			booking.BookingID,
+			height)
+	}
+
+	return booking, nil
+}
+
+// setBooking stores an updated booking
+func (k Keeper) setBooking(ctx sdk.Context, booking types.Booking) error {
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
+	if err != nil {
+		return fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
+	}
+	return nil
+}
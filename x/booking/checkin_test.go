@@ -0,0 +1,104 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// deviceSign signs *signBytes* as the device of an asset does
+func deviceSign(t *testing.T, privKey types.PrivKeySecp256k1, signBytes []byte) types.SignatureSecp256k1 {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), privKey[:])
+
+	sig, err := key.Sign(crypto.Sha256(signBytes))
+	if err != nil {
+		t.Fatalf("Signing failed. %s", err)
+	}
+	return types.SignatureSecp256k1(sig.Serialize())
+}
+
+func TestCheckInCheckOut(t *testing.T) {
+	in := setupTestInput(t)
+
+	devicePub, devicePriv := types.GenerateKeyPair()
+	_, otherPriv := types.GenerateKeyPair()
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.DevicePubKey = devicePub
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	height := in.ctx.BlockHeight()
+	checkInBytes := msg.DeviceSignBytes(msg.ActionCheckIn, booking.BookingID, height)
+	checkOutBytes := msg.DeviceSignBytes(msg.ActionCheckOut, booking.BookingID, height)
+
+	checkIn := msg.NewMsgCheckIn(booking.BookingID, height, deviceSign(t, devicePriv, checkInBytes))
+	checkOut := msg.NewMsgCheckOut(booking.BookingID, height, deviceSign(t, devicePriv, checkOutBytes))
+
+	if _, err := in.k.CheckOut(in.signedBy(testRenter), checkOut); err == nil {
+		t.Errorf("Checking out without checking in should fail.")
+	}
+
+	if _, err := in.k.CheckIn(in.signedBy(testRenter), checkIn); err == nil {
+		t.Errorf("Checking in before the booking starts should fail.")
+	}
+
+	in.setTime(testNow + testHour)
+
+	forged := msg.NewMsgCheckIn(booking.BookingID, height, deviceSign(t, otherPriv, checkInBytes))
+	if _, err := in.k.CheckIn(in.signedBy(testRenter), forged); err == nil {
+		t.Errorf("Checking in with an attestation of another device should fail.")
+	}
+
+	// an attestation of the check-out does not check in
+	swapped := msg.NewMsgCheckIn(booking.BookingID, height, checkOut.DeviceSignature)
+	if _, err := in.k.CheckIn(in.signedBy(testRenter), swapped); err == nil {
+		t.Errorf("Checking in with a check-out attestation should fail.")
+	}
+
+	booking, err = in.k.CheckIn(in.signedBy(testRenter), checkIn)
+	if err != nil {
+		t.Fatalf("Checking in failed. %s", err)
+	}
+	if booking.GetStatus() != constants.BOOKING_STATUS_CHECKED_IN || booking.CheckInHeight != height {
+		t.Errorf("Booking should be checked in at %d, got %s at %d.", height, booking.GetStatus(), booking.CheckInHeight)
+	}
+
+	if _, err := in.k.CheckIn(in.signedBy(testRenter), checkIn); err == nil {
+		t.Errorf("Replaying a check-in attestation should fail.")
+	}
+
+	if _, err := in.k.CheckOut(in.signedBy(testRenter), checkOut); err != nil {
+		t.Fatalf("Checking out failed. %s", err)
+	}
+
+	if _, err := in.k.CheckOut(in.signedBy(testRenter), checkOut); err == nil {
+		t.Errorf("Replaying a check-out attestation should fail.")
+	}
+
+	// attestations are only valid for a few blocks
+	in.setHeight(height + constants.BOOKING_ATTESTATION_WINDOW + 1)
+
+	second, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	in.setTime(testNow + 4*testHour)
+
+	stale := msg.NewMsgCheckIn(second.BookingID, height,
+		deviceSign(t, devicePriv, msg.DeviceSignBytes(msg.ActionCheckIn, second.BookingID, height)))
+	if _, err := in.k.CheckIn(in.signedBy(testRenter), stale); err == nil {
+		t.Errorf("Checking in with a stale attestation should fail.")
+	}
+}
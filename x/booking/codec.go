@@ -8,5 +8,13 @@ import (
 func RegisterCodec(cdc *amino.Codec) *amino.Codec {
 	cdc.RegisterConcrete(msg.MsgBook{}, "shareledger/booking/MsgBook", nil)
 	cdc.RegisterConcrete(msg.MsgComplete{}, "shareledger/booking/MsgComplete", nil)
+	cdc.RegisterConcrete(msg.MsgCancelBooking{}, "shareledger/booking/MsgCancelBooking", nil)
+	cdc.RegisterConcrete(msg.MsgClaimDeposit{}, "shareledger/booking/MsgClaimDeposit", nil)
+	cdc.RegisterConcrete(msg.MsgConfirmBooking{}, "shareledger/booking/MsgConfirmBooking", nil)
+	cdc.RegisterConcrete(msg.MsgRejectBooking{}, "shareledger/booking/MsgRejectBooking", nil)
+	cdc.RegisterConcrete(msg.MsgCheckIn{}, "shareledger/booking/MsgCheckIn", nil)
+	cdc.RegisterConcrete(msg.MsgCheckOut{}, "shareledger/booking/MsgCheckOut", nil)
+	cdc.RegisterConcrete(msg.MsgSubscribe{}, "shareledger/booking/MsgSubscribe", nil)
+	cdc.RegisterConcrete(msg.MsgCancelSubscription{}, "shareledger/booking/MsgCancelSubscription", nil)
 	return cdc
 }
@@ -0,0 +1,136 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/booking/tags"
+)
+
+// ClaimDeposit - owner of an asset claims part of the deposit of a completed booking
+// as compensation for damages. Claims are accepted until the claim window closes.
+func (k Keeper) ClaimDeposit(ctx sdk.Context, msg msg.MsgClaimDeposit) (types.Booking, error) {
+
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
+	}
+
+	if booking.GetStatus() == constants.BOOKING_STATUS_DISPUTED {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_DISPUTED_ERROR,
+			booking.BookingID)
+	}
+
+	if booking.GetStatus() != constants.BOOKING_STATUS_COMPLETED {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_COMPLETED,
+			booking.BookingID)
+	}
+
+	now := ctx.BlockHeader().Time.Unix()
+	if booking.ClaimDeadline <= now {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_WINDOW_CLOSED,
+			booking.BookingID,
+			booking.ClaimDeadline)
+	}
+
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
+			constants.STORE_ASSET)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(signer, asset.Creator) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_UNAUTHORIZED,
+			booking.BookingID,
+			utils.ByteToString(signer))
+	}
+
+	claim := types.NewCoin(booking.Deposit.Denom, msg.Amount)
+	remaining := booking.RemainingDeposit()
+
+	if claim.GT(remaining) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CLAIM_EXCEEDS_DEPOSIT,
+			claim.String(),
+			remaining.String(),
+			booking.BookingID)
+	}
+
+	booking.DepositClaimed = booking.DepositClaimed.Plus(claim)
+
+	// Nothing left to return to the renter
+	if claim.Equal(remaining) {
+		k.removeDepositQueue(ctx, booking)
+	}
+
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
+	if err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
+	}
+
+	if err := k.releaseFromEscrow(ctx, asset.Creator, claim); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ReturnExpiredDeposits - return unclaimed deposits to renters once their claim window is closed
+func (k Keeper) ReturnExpiredDeposits(ctx sdk.Context) sdk.Tags {
+	store := ctx.KVStore(k.bookingKey)
+	now := ctx.BlockHeader().Time.Unix()
+
+	// collect first, the queue is modified while returning deposits
+	var expired []types.Booking
+
+	iterator := store.Iterator(DepositQueueKey, GetDepositQueueTimeKey(now+1))
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, k.mustGetBooking(ctx, string(iterator.Value())))
+	}
+	iterator.Close()
+
+	resTags := sdk.EmptyTags()
+
+	for _, booking := range expired {
+		remaining := booking.RemainingDeposit()
+
+		k.removeDepositQueue(ctx, booking)
+
+		if err := k.releaseFromEscrow(ctx, booking.Renter, remaining); err != nil {
+			panic(err)
+		}
+
+		resTags = resTags.
+			AppendTag(tags.Event, tags.DepositReturned).
+			AppendTag(tags.BookingId, booking.BookingID).
+			AppendTag(tags.Amount, remaining.String())
+	}
+
+	return resTags
+}
+
+// setDepositQueue puts the deposit of a completed booking in its claim window
+func (k Keeper) setDepositQueue(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetDepositQueueKey(booking.ClaimDeadline, booking.BookingID), []byte(booking.BookingID))
+}
+
+// removeDepositQueue takes the deposit of a booking out of its claim window
+func (k Keeper) removeDepositQueue(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetDepositQueueKey(booking.ClaimDeadline, booking.BookingID))
+}
@@ -0,0 +1,82 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+func TestClaimDeposit(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.Deposit = 5
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testOwner), msg.NewMsgClaimDeposit(booking.BookingID, 1, nil)); err == nil {
+		t.Errorf("Claiming the deposit of a booking which is not completed should fail.")
+	}
+
+	booking, err = in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(booking.BookingID))
+	if err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+
+	if booking.ClaimDeadline != testNow+constants.DEPOSIT_CLAIM_WINDOW {
+		t.Errorf("Claim window should close %d seconds after completion, closes at %d.",
+			constants.DEPOSIT_CLAIM_WINDOW, booking.ClaimDeadline)
+	}
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testRenter), msg.NewMsgClaimDeposit(booking.BookingID, 1, nil)); err == nil {
+		t.Errorf("Renter claiming the deposit should fail.")
+	}
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testOwner), msg.NewMsgClaimDeposit(booking.BookingID, 6, nil)); err == nil {
+		t.Errorf("Claiming more than the deposit should fail.")
+	}
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testOwner), msg.NewMsgClaimDeposit(booking.BookingID, 3, []byte("evidence"))); err != nil {
+		t.Fatalf("Claiming part of the deposit failed. %s", err)
+	}
+
+	// payment and the claimed part of the deposit
+	if !in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 23)) {
+		t.Errorf("Owner should have 23, got %s.", in.balance(testOwner).String())
+	}
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testOwner), msg.NewMsgClaimDeposit(booking.BookingID, 3, nil)); err == nil {
+		t.Errorf("Claiming more than what is left of the deposit should fail.")
+	}
+	in.checkInvariants(t)
+
+	// the rest stays in escrow until the window closes
+	in.setTime(booking.ClaimDeadline - 1)
+	in.k.ReturnExpiredDeposits(in.ctx)
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 75)) {
+		t.Errorf("Deposit should not be returned before the window closes, renter has %s.", in.balance(testRenter).String())
+	}
+
+	in.setTime(booking.ClaimDeadline)
+
+	if _, err := in.k.ClaimDeposit(in.signedBy(testOwner), msg.NewMsgClaimDeposit(booking.BookingID, 1, nil)); err == nil {
+		t.Errorf("Claiming once the window closed should fail.")
+	}
+
+	in.k.ReturnExpiredDeposits(in.ctx)
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 77)) ||
+		!in.k.GetEscrow(in.ctx).GetCoin(constants.BOOKING_DENOM).IsZero() {
+		t.Errorf("Renter should get the unclaimed 2 back, has %s.", in.balance(testRenter).String())
+	}
+	in.checkInvariants(t)
+}
@@ -0,0 +1,138 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// EscrowAddress - account holding the payments of bookings which are not yet completed
+// and of subscription periods which have not yet elapsed.
+// No private key exists for this address, only the booking module moves its coins.
+var EscrowAddress = sdk.AccAddress(crypto.AddressHash([]byte("booking/escrow")))
+
+// GetEscrow returns the coins currently held by the booking escrow
+func (k Keeper) GetEscrow(ctx sdk.Context) types.Coins {
+	return k.bankKeeper.GetCoins(ctx, EscrowAddress)
+}
+
+// GetEscrowOwed returns the sum of payments and deposits held for bookings which are not yet completed,
+// together with the deposits of completed bookings still in their claim window
+// and the current periods of active subscriptions
+func (k Keeper) GetEscrowOwed(ctx sdk.Context) types.Coins {
+	owed := types.NewDefaultCoins()
+
+	store := ctx.KVStore(k.bookingKey)
+
+	calendar := sdk.KVStorePrefixIterator(store, CalendarKey)
+	defer calendar.Close()
+
+	for ; calendar.Valid(); calendar.Next() {
+		booking := k.mustGetBooking(ctx, string(calendar.Value()))
+		owed = owed.Plus(booking.Payment)
+		owed = owed.Plus(booking.Deposit)
+	}
+
+	deposits := sdk.KVStorePrefixIterator(store, DepositQueueKey)
+	defer deposits.Close()
+
+	for ; deposits.Valid(); deposits.Next() {
+		booking := k.mustGetBooking(ctx, string(deposits.Value()))
+		owed = owed.Plus(booking.RemainingDeposit())
+	}
+
+	subscriptions := sdk.KVStorePrefixIterator(store, SubscriptionByAssetKey)
+	defer subscriptions.Close()
+
+	for ; subscriptions.Valid(); subscriptions.Next() {
+		subscription, found := k.GetSubscription(ctx, string(subscriptions.Value()))
+		if !found {
+			panic(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, string(subscriptions.Value()), constants.STORE_BOOKING))
+		}
+		if subscription.HasHeldPeriod() {
+			owed = owed.Plus(subscription.Held)
+		}
+	}
+	return owed
+}
+
+// mustGetBooking returns a booking referenced by an index, which must exist
+func (k Keeper) mustGetBooking(ctx sdk.Context, bookingID string) types.Booking {
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		panic(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, bookingID, constants.STORE_BOOKING))
+	}
+	return booking
+}
+
+// holdInEscrow moves a payment from an account to the booking escrow
+func (k Keeper) holdInEscrow(ctx sdk.Context, from sdk.AccAddress, amt types.Coin) sdk.Error {
+	_, err := k.bankKeeper.SubtractCoin(ctx, from, amt)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.bankKeeper.AddCoin(ctx, EscrowAddress, amt)
+	return err
+}
+
+// releaseFromEscrow moves a payment from the booking escrow to an account
+func (k Keeper) releaseFromEscrow(ctx sdk.Context, to sdk.AccAddress, amt types.Coin) sdk.Error {
+	_, err := k.bankKeeper.SubtractCoin(ctx, EscrowAddress, amt)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.bankKeeper.AddCoin(ctx, to, amt)
+	return err
+}
+
+// releasePayment pays part of the payment of a booking out of the escrow.
+// Legacy bookings took their payment from the renter without holding it anywhere,
+// it is credited back to the supply instead, as the owner was paid before the escrow existed.
+func (k Keeper) releasePayment(ctx sdk.Context, booking types.Booking, to sdk.AccAddress, amt types.Coin) sdk.Error {
+	if !booking.IsLegacy() {
+		return k.releaseFromEscrow(ctx, to, amt)
+	}
+
+	_, err := k.bankKeeper.AddCoin(ctx, to, amt)
+	if err != nil {
+		return err
+	}
+
+	k.bankKeeper.AddSupply(ctx, amt)
+	return nil
+}
+
+// setLegacyPayment records the payment of a legacy booking, which was not stored at booking.
+// The renter was charged the duration at the asset fee and locked no deposit.
+func setLegacyPayment(booking *types.Booking, asset types.Asset) {
+	if !booking.IsLegacy() || booking.Payment.Denom != "" {
+		return
+	}
+
+	booking.Payment = types.NewCoin(constants.BOOKING_DENOM, booking.Duration*asset.Fee)
+	booking.Deposit = types.NewCoin(constants.BOOKING_DENOM, 0)
+	booking.DepositClaimed = types.NewCoin(constants.BOOKING_DENOM, 0)
+}
+
+// EscrowInvariant checks that the escrow holds exactly what is owed to open bookings and subscriptions.
+// Bookings only move coins in and out of the escrow, so any difference means
+// coins have been created or destroyed.
+func EscrowInvariant(ctx sdk.Context, k Keeper) error {
+	held := k.GetEscrow(ctx)
+	owed := k.GetEscrowOwed(ctx)
+
+	for _, denom := range constants.ALL_DENOMS {
+		if !held.GetCoin(denom).Equal(owed.GetCoin(denom)) {
+			return fmt.Errorf(constants.BOOKING_ESCROW_MISMATCH,
+				held.String(),
+				owed.String())
+		}
+	}
+	return nil
+}
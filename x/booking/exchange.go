@@ -0,0 +1,76 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	etypes "github.com/sharering/shareledger/x/exchange/types"
+)
+
+// convertPayment makes sure *renter* holds *owed*, in the asset price denom.
+// The renter pays in the denom of *maxPayment*, bought from a reserve at the rate of the
+// exchange rate table, and never pays more than *maxPayment*. Without a maximum
+// the renter pays in the price denom directly. Returns what the renter paid.
+func (k Keeper) convertPayment(
+	ctx sdk.Context,
+	renter sdk.AccAddress,
+	owed types.Coin,
+	maxPayment types.Coin,
+) (
+	types.Coin, error,
+) {
+	if maxPayment.Denom == "" {
+		return owed, nil
+	}
+
+	paid := owed
+	if maxPayment.Denom != owed.Denom {
+		exr, err := k.exchangeKeeper.RetrieveExchangeRate(ctx, maxPayment.Denom, owed.Denom)
+		if err != nil {
+			return types.Coin{}, err
+		}
+		paid = exr.Obtain(owed)
+	}
+
+	// rates or asset prices changed since the renter signed
+	if paid.GT(maxPayment) {
+		return types.Coin{}, fmt.Errorf(constants.BOOKING_SLIPPAGE_EXCEEDED,
+			paid.String(),
+			maxPayment.String())
+	}
+
+	if paid.Denom == owed.Denom || !owed.IsPositive() {
+		return paid, nil
+	}
+
+	reserve, found := k.getReserveHolding(ctx, owed)
+	if !found {
+		return types.Coin{}, fmt.Errorf(constants.BOOKING_NO_RESERVE,
+			owed.String())
+	}
+
+	err := k.exchangeKeeper.BuyCoin(ctx,
+		renter,
+		reserve.Address,
+		maxPayment.Denom,
+		owed.Denom,
+		owed.Amount)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	return paid, nil
+}
+
+// getReserveHolding returns the first reserve, in configuration order, holding at least *amt*
+func (k Keeper) getReserveHolding(ctx sdk.Context, amt types.Coin) (etypes.Reserve, bool) {
+	for _, reserve := range etypes.GetAllReserve() {
+		if reserve.GetCoins(ctx, k.bankKeeper).GTE(amt) {
+			return reserve, true
+		}
+	}
+	return etypes.Reserve{}, false
+}
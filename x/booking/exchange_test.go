@@ -0,0 +1,89 @@
+package booking
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+	etypes "github.com/sharering/shareledger/x/exchange/types"
+)
+
+// coinOf parses *amount* as a decimal coin of *denom*
+func coinOf(t *testing.T, denom string, amount string) types.Coin {
+	dec, err := types.NewDecFromStr(amount)
+	if err != nil {
+		t.Fatalf("Parsing %s failed. %s", amount, err)
+	}
+	return types.NewCoinFromDec(denom, dec)
+}
+
+func TestBookPaysInOtherDenom(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+
+	reserve, err := sdk.AccAddressFromHex(constants.RESERVE_ACCOUNTS[0])
+	if err != nil {
+		t.Fatalf("Decoding reserve failed. %s", err)
+	}
+	in.fund(t, reserve, types.NewCoin(constants.BOOKING_DENOM, 100))
+	in.fund(t, testRenter, types.NewCoin(constants.DEFAULT_DENOM, 100))
+
+	bookWith := func(maxPayment types.Coin) (types.Booking, error) {
+		book := msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour)
+		book.MaxPayment = maxPayment
+		return in.k.Book(in.signedBy(testRenter), book)
+	}
+
+	if _, err := bookWith(types.NewCoin(constants.DEFAULT_DENOM, 100)); err == nil {
+		t.Errorf("Paying in a denom without an exchange rate should fail.")
+	}
+
+	rate, _ := types.NewDecFromStr("0.3")
+	if err := in.k.exchangeKeeper.Store(in.ctx, etypes.NewExchangeRate(constants.DEFAULT_DENOM, constants.BOOKING_DENOM, rate)); err != nil {
+		t.Fatalf("Storing exchange rate failed. %s", err)
+	}
+
+	// signed when the rate was 0.4, 20 SHRP cost 50 SHR then
+	if _, err := bookWith(types.NewCoin(constants.DEFAULT_DENOM, 50)); err == nil {
+		t.Errorf("Paying more than the maximum signed at a stale rate should fail.")
+	}
+
+	// 20 / 0.3 rounds up in the last decimal, against the renter
+	if _, err := bookWith(coinOf(t, constants.DEFAULT_DENOM, "66.6666666666")); err == nil {
+		t.Errorf("Maximum below the rounded conversion should fail.")
+	}
+
+	paid := coinOf(t, constants.DEFAULT_DENOM, "66.6666666667")
+	booking, err := bookWith(paid)
+	if err != nil {
+		t.Fatalf("Paying in another denom failed. %s", err)
+	}
+
+	if !booking.Paid.Equal(paid) || !booking.Payment.Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Renter should pay %s for 20%s, paid %s for %s.",
+			paid.String(), constants.BOOKING_DENOM, booking.Paid.String(), booking.Payment.String())
+	}
+
+	renter := in.bk.GetCoins(in.ctx, testRenter)
+	if !renter.GetCoin(constants.DEFAULT_DENOM).Equal(coinOf(t, constants.DEFAULT_DENOM, "33.3333333333")) ||
+		!renter.GetCoin(constants.BOOKING_DENOM).IsZero() {
+		t.Errorf("Renter should only spend the converted payment, has %s.", renter.String())
+	}
+
+	if !in.balance(reserve).Equal(types.NewCoin(constants.BOOKING_DENOM, 80)) {
+		t.Errorf("Reserve should sell 20%s, has %s.", constants.BOOKING_DENOM, in.balance(reserve).String())
+	}
+	in.checkInvariants(t)
+
+	// owner is paid in the price denom
+	if _, err := in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(booking.BookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+	if !in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Owner should get 20%s, got %s.", constants.BOOKING_DENOM, in.balance(testOwner).String())
+	}
+	in.checkInvariants(t)
+}
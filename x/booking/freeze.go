@@ -0,0 +1,78 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+// Freeze - take everything held in escrow for a booking out of the normal booking flow
+// and move it to *holder*, which settles it from then on. The booking is marked as disputed
+// so that it can no longer be completed, cancelled or claimed against.
+func (k Keeper) Freeze(ctx sdk.Context, bookingID string, holder sdk.AccAddress) (types.Booking, types.Coin, error) {
+
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	booking, found := k.GetBooking(ctx, bookingID)
+	if !found {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			bookingID,
+			constants.STORE_BOOKING)
+	}
+
+	// Legacy bookings hold nothing in escrow
+	if booking.IsLegacy() {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.BOOKING_NOTHING_HELD,
+			booking.BookingID)
+	}
+
+	previous := booking.GetStatus()
+
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_DISPUTED); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
+
+	var held types.Coin
+
+	switch {
+	case previous != constants.BOOKING_STATUS_COMPLETED:
+		// payment and deposit are held until the booking is completed
+		held = booking.Payment.Plus(booking.Deposit)
+
+	case booking.ClaimDeadline > ctx.BlockHeader().Time.Unix():
+		// what is left of the deposit is held until the claim window closes
+		held = booking.RemainingDeposit()
+
+	default:
+		held = types.NewCoin(constants.BOOKING_DENOM, 0)
+	}
+
+	if held.IsNil() || !held.IsPositive() {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.BOOKING_NOTHING_HELD,
+			booking.BookingID)
+	}
+
+	// Held funds are no longer tracked by the calendar or the deposit queue
+	if previous == constants.BOOKING_STATUS_COMPLETED {
+		k.removeDepositQueue(ctx, booking)
+	} else {
+		k.removeCalendarSlot(ctx, booking)
+	}
+
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
+	if err != nil {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
+	}
+
+	if err := k.releaseFromEscrow(ctx, holder, held); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
+
+	return booking, held, nil
+}
@@ -1,14 +1,16 @@
 package booking
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/sharering/shareledger/constants"
-	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/booking/tags"
 
 	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
 )
@@ -29,23 +31,30 @@ func NewHandler(k Keeper) sdkTypes.Handler {
 			ret = handleBooking(ctx, k, msg)
 		case messages.MsgComplete:
 			ret = handleComplete(ctx, k, msg)
+		case messages.MsgCancelBooking:
+			ret = handleCancel(ctx, k, msg)
+		case messages.MsgClaimDeposit:
+			ret = handleClaimDeposit(ctx, k, msg)
+		case messages.MsgConfirmBooking:
+			ret = handleConfirm(ctx, k, msg)
+		case messages.MsgRejectBooking:
+			ret = handleReject(ctx, k, msg)
+		case messages.MsgCheckIn:
+			ret = handleCheckIn(ctx, k, msg)
+		case messages.MsgCheckOut:
+			ret = handleCheckOut(ctx, k, msg)
+		case messages.MsgSubscribe:
+			ret = handleSubscribe(ctx, k, msg)
+		case messages.MsgCancelSubscription:
+			ret = handleCancelSubscription(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
 			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
 		}
 
-		if !ret.IsOK() {
-			return sdkTypes.NewResult(ret)
-		}
-
-		fee, denom := utils.GetMsgFee(msg)
-
-		return sdkTypes.Result{
-			Result: ret,
-			FeeDenom: denom,
-			FeeAmount: fee,
-		}
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(ret)
 
 	}
 }
@@ -76,12 +85,143 @@ func handleComplete(ctx sdk.Context, k Keeper, msg messages.MsgComplete) sdk.Res
 		return sdk.ErrInternal(err.Error()).Result()
 	}
 
+	resTags := msg.Tags()
+
+	// completed by the asset owner without the renter checking out
+	if !bytes.Equal(auth.GetSigner(ctx).GetAddress(), booking.Renter) {
+		resTags = resTags.AppendTag(tags.Override, "true")
+	}
+
 	// fee, denom := utils.GetMsgFee(msg)
 
 	return sdk.Result{
 		Log:  fmt.Sprintf("Completed %s", booking.String()),
-		Tags: msg.Tags(),
+		Tags: resTags,
 		// FeeAmount: fee,
 		// FeeDenom:  denom,
 	}
 }
+
+func handleCancel(ctx sdk.Context, k Keeper, msg messages.MsgCancelBooking) sdk.Result {
+
+	booking, refund, err := k.Cancel(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Cancelled %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, booking.UUID).
+			AppendTag(tags.Signer, auth.GetSigner(ctx).GetAddress().String()).
+			AppendTag(tags.Refund, refund.String()).
+			AppendTag(tags.Payout, booking.Payment.Minus(refund).String()),
+	}
+}
+
+func handleClaimDeposit(ctx sdk.Context, k Keeper, msg messages.MsgClaimDeposit) sdk.Result {
+
+	booking, err := k.ClaimDeposit(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("Claimed deposit of %s", booking.String()),
+		Tags: msg.Tags(),
+	}
+}
+
+func handleConfirm(ctx sdk.Context, k Keeper, msg messages.MsgConfirmBooking) sdk.Result {
+
+	booking, err := k.Confirm(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Confirmed %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, booking.UUID),
+	}
+}
+
+func handleReject(ctx sdk.Context, k Keeper, msg messages.MsgRejectBooking) sdk.Result {
+
+	booking, err := k.Reject(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Rejected %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, booking.UUID).
+			AppendTag(tags.Refund, booking.Payment.Plus(booking.Deposit).String()),
+	}
+}
+
+func handleCheckIn(ctx sdk.Context, k Keeper, msg messages.MsgCheckIn) sdk.Result {
+
+	booking, err := k.CheckIn(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Checked in %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, booking.UUID),
+	}
+}
+
+func handleCheckOut(ctx sdk.Context, k Keeper, msg messages.MsgCheckOut) sdk.Result {
+
+	booking, err := k.CheckOut(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Checked out %s", booking.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, booking.UUID),
+	}
+}
+
+func handleSubscribe(ctx sdk.Context, k Keeper, msg messages.MsgSubscribe) sdk.Result {
+
+	subscription, err := k.Subscribe(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("%s", subscription.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.SubscriptionId, subscription.SubscriptionID).
+			AppendTag(tags.Amount, subscription.Amount.String()),
+	}
+}
+
+func handleCancelSubscription(ctx sdk.Context, k Keeper, msg messages.MsgCancelSubscription) sdk.Result {
+
+	subscription, err := k.CancelSubscription(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("Cancelled %s", subscription.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, subscription.UUID),
+	}
+}
@@ -0,0 +1,133 @@
+package booking
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+)
+
+// GenBookingID - full sha256 of asset UUID, renter, renter nonce, block height and
+// sequence in the transaction. Nonce and height make the ID unique for every transaction
+// of a renter, the sequence for every message of a transaction.
+func GenBookingID(uuid string, renter sdk.AccAddress, nonce int64, height int64, seq int64) string {
+	h := sha256.New()
+
+	h.Write(lengthPrefixed(uuid))
+	h.Write(renter.Bytes())
+	h.Write(int64ToBytes(nonce))
+	h.Write(int64ToBytes(height))
+	h.Write(int64ToBytes(seq))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// txNonce returns the account which signed the transaction and its nonce, already increased by the ante handler.
+// Under a grant this is the grantee, whose nonce changes with every transaction, unlike the granter one.
+func txNonce(ctx sdk.Context) (sdk.AccAddress, int64) {
+	signer := auth.GetSigner(ctx)
+	if grantee := auth.GetGrantee(ctx); grantee != nil {
+		signer = grantee
+	}
+	return signer.GetAddress(), signer.GetNonce()
+}
+
+// resolveLegacyID returns the full length ID a legacy short ID was migrated to
+func (k Keeper) resolveLegacyID(ctx sdk.Context, bookingID string) (string, bool) {
+	store := ctx.KVStore(k.bookingKey)
+
+	bz := store.Get(GetLegacyIDKey(bookingID))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// IsMigrated - whether legacy booking IDs were already migrated
+func (k Keeper) IsMigrated(ctx sdk.Context) bool {
+	return ctx.KVStore(k.bookingKey).Has(MigrationKey)
+}
+
+// MigrateLegacyBookings - move bookings stored under legacy short IDs to full length IDs.
+// Short IDs keep resolving through an alias to the new ID. The height of the migration is recorded.
+func (k Keeper) MigrateLegacyBookings(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(k.bookingKey)
+
+	// collect first, the store is modified while migrating
+	var legacy []types.Booking
+
+	// IDs are printable, every key from BookingKeyStart on is a booking
+	iterator := store.Iterator(BookingKeyStart, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		if len(iterator.Key()) != constants.LEGACY_BOOKING_ID_LENGTH {
+			continue
+		}
+
+		var booking types.Booking
+
+		err := utils.Retrieve(store, iterator.Key(), &booking)
+		if err != nil {
+			panic(err)
+		}
+
+		if booking.BookingID == string(iterator.Key()) {
+			legacy = append(legacy, booking)
+		}
+	}
+	iterator.Close()
+
+	for _, booking := range legacy {
+		shortID := booking.BookingID
+		old := booking
+
+		booking.BookingID = legacyToFullID(shortID)
+
+		err := utils.Store(store, []byte(booking.BookingID), booking)
+		if err != nil {
+			panic(err)
+		}
+		store.Delete([]byte(shortID))
+
+		// indexes referencing the booking follow it to its new ID
+		moveIndex(store, GetCalendarKey(old.UUID, old.Start, shortID),
+			GetCalendarKey(booking.UUID, booking.Start, booking.BookingID), booking.BookingID)
+		moveIndex(store, GetDepositQueueKey(old.ClaimDeadline, shortID),
+			GetDepositQueueKey(booking.ClaimDeadline, booking.BookingID), booking.BookingID)
+		moveIndex(store, GetByRenterKey(old.Renter, shortID),
+			GetByRenterKey(booking.Renter, booking.BookingID), booking.BookingID)
+		moveIndex(store, GetByAssetKey(old.UUID, shortID),
+			GetByAssetKey(booking.UUID, booking.BookingID), booking.BookingID)
+
+		// bookings made before the renter and asset indexes existed are referenced from now on
+		k.setIndexes(ctx, booking)
+
+		store.Set(GetLegacyIDKey(shortID), []byte(booking.BookingID))
+
+		migrated++
+	}
+
+	store.Set(MigrationKey, int64ToBytes(ctx.BlockHeight()))
+
+	return migrated
+}
+
+// legacyToFullID - full length ID of a booking migrated from a legacy short ID.
+// Short IDs are unique, so are their hashes.
+func legacyToFullID(shortID string) string {
+	hash := sha256.Sum256([]byte("legacy/" + shortID))
+	return hex.EncodeToString(hash[:])
+}
+
+// moveIndex moves an index entry, if present, to a new key
+func moveIndex(store sdk.KVStore, oldKey []byte, newKey []byte, value string) {
+	if !store.Has(oldKey) {
+		return
+	}
+	store.Delete(oldKey)
+	store.Set(newKey, []byte(value))
+}
@@ -0,0 +1,54 @@
+package booking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// GetBookingsByRenter returns the bookings of a renter matching a status filter
+func (k Keeper) GetBookingsByRenter(ctx sdk.Context, renter sdk.AccAddress, status string) []types.Booking {
+	return k.getBookingsByPrefix(ctx, GetByRenterPrefix(renter), status)
+}
+
+// GetBookingsByAsset returns the bookings of an asset matching a status filter
+func (k Keeper) GetBookingsByAsset(ctx sdk.Context, uuid string, status string) []types.Booking {
+	return k.getBookingsByPrefix(ctx, GetByAssetPrefix(uuid), status)
+}
+
+// getBookingsByPrefix returns bookings referenced by the index under prefix.
+// status is BOOKING_FILTER_ACTIVE, a booking status or empty for all bookings
+func (k Keeper) getBookingsByPrefix(ctx sdk.Context, prefix []byte, status string) []types.Booking {
+	store := ctx.KVStore(k.bookingKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	bookings := []types.Booking{}
+
+	for ; iterator.Valid(); iterator.Next() {
+		booking := k.mustGetBooking(ctx, string(iterator.Value()))
+
+		switch status {
+		case "":
+		case constants.BOOKING_FILTER_ACTIVE:
+			if !booking.IsActive() {
+				continue
+			}
+		default:
+			if booking.GetStatus() != status {
+				continue
+			}
+		}
+
+		bookings = append(bookings, booking)
+	}
+	return bookings
+}
+
+// setIndexes references a booking from its renter and asset
+func (k Keeper) setIndexes(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetByRenterKey(booking.Renter, booking.BookingID), []byte(booking.BookingID))
+	store.Set(GetByAssetKey(booking.UUID, booking.BookingID), []byte(booking.BookingID))
+}
@@ -12,27 +12,60 @@ import (
 	"github.com/sharering/shareledger/types"
 	utils "github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
 	msg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/exchange"
 )
 
 type Keeper struct {
-	bookingKey sdk.StoreKey // key used to access the store from the context
-	assetKey   sdk.StoreKey // asset key
-	//accountKey sdk.StoreKey // account key
-	accountMapper auth.AccountMapper // account mapper
-	cdc           *amino.Codec
+	bookingKey     sdk.StoreKey    // key used to access the store from the context
+	assetKey       sdk.StoreKey    // asset key
+	bankKeeper     bank.Keeper     // moves payments in and out of the escrow
+	exchangeKeeper exchange.Keeper // converts payments made in another denom than the asset price
+	cdc            *amino.Codec
 }
 
-func NewKeeper(bookingKey sdk.StoreKey, assetKey sdk.StoreKey, am auth.AccountMapper, cdc *amino.Codec) Keeper {
+func NewKeeper(bookingKey sdk.StoreKey, assetKey sdk.StoreKey, bk bank.Keeper, ek exchange.Keeper, cdc *amino.Codec) Keeper {
 	return Keeper{
-		bookingKey:    bookingKey,
-		assetKey:      assetKey,
-		accountMapper: am,
-		//accountKey: accountKey,
-		cdc: cdc,
+		bookingKey:     bookingKey,
+		assetKey:       assetKey,
+		bankKeeper:     bk,
+		exchangeKeeper: ek,
+		cdc:            cdc,
 	}
 }
 
+// GetBooking returns the booking stored under bookingID.
+// A legacy short ID resolves to the booking it was migrated to.
+func (k Keeper) GetBooking(ctx sdk.Context, bookingID string) (types.Booking, bool) {
+	store := ctx.KVStore(k.bookingKey)
+
+	if fullID, found := k.resolveLegacyID(ctx, bookingID); found {
+		bookingID = fullID
+	}
+
+	var booking types.Booking
+
+	err := utils.Retrieve(store, []byte(bookingID), &booking)
+	if err != nil || booking.BookingID == "" {
+		return types.Booking{}, false
+	}
+	return booking, true
+}
+
+// GetAsset returns the asset stored under uuid
+func (k Keeper) GetAsset(ctx sdk.Context, uuid string) (types.Asset, bool) {
+	store := ctx.KVStore(k.assetKey)
+
+	var asset types.Asset
+
+	err := utils.Retrieve(store, []byte(uuid), &asset)
+	if err != nil || asset.UUID == "" {
+		return types.Asset{}, false
+	}
+	return asset, true
+}
+
 //-----------------------------------------------
 
 func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
@@ -40,17 +73,23 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 	bookingStore := ctx.KVStore(k.bookingKey)
 	assetStore := ctx.KVStore(k.assetKey)
 
-	bookingId, err := utils.GenUUID(msg)
+	// For a booking, renter is the account signing this message
+	renter := auth.GetSigner(ctx)
 
-	if err != nil {
-		return types.Booking{}, fmt.Errorf("bookingID generation failed %s", err.Error())
+	// Nonce was already increased by the ante handler, it is unique per signed transaction
+	signer, nonce := txNonce(ctx)
+	bookingId := GenBookingID(msg.UUID, signer, nonce, ctx.BlockHeight(), auth.NextIDSeq(ctx))
+
+	if bookingStore.Has([]byte(bookingId)) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ID_COLLISION,
+			bookingId)
 	}
 
 	// Checking asset
 
 	var asset types.Asset
 
-	err = utils.Retrieve(assetStore, []byte(msg.UUID), &asset)
+	err := utils.Retrieve(assetStore, []byte(msg.UUID), &asset)
 
 	if err != nil {
 		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
@@ -64,47 +103,78 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 			constants.STORE_BOOKING)
 	}
 
+	// Status is the owner's switch to accept bookings on this asset.
+	// A legacy booking also holds its asset by clearing it until the booking ends
 	if asset.Status == false {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_RENTED,
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_UNAVAILABLE,
 			asset.UUID)
 	}
 
-	// For a booking, renter is the account signing this message
-	renter := auth.GetSigner(ctx)
-
-	// renter account
-	renterAcc := k.accountMapper.GetAccount(ctx, renter.GetAddress())
-
-	if renterAcc == nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
-			utils.ByteToString(renter.GetAddress()),
-			constants.STORE_BANK)
+	// A subscribed asset stays with its subscriber until the subscription ends
+	if other, found := k.getActiveSubscription(ctx, asset.UUID); found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_SUBSCRIBED,
+			asset.UUID,
+			other.SubscriptionID)
 	}
 
-	// Calculate fee and deduce from Renter account
-	value := msg.Duration * asset.Fee
-
-	renterCoins := renterAcc.GetCoins()
-
-	// fmt.Printf("RENTER COINS: %v\n", renterCoins)
-
-	renterCoinsAfter := renterCoins.Minus(types.NewCoin(constants.BOOKING_DENOM, value))
+	// A booking cannot start before the current block
+	now := ctx.BlockHeader().Time.Unix()
+	if msg.Start < now {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_START_PASSED,
+			msg.Start,
+			now)
+	}
 
-	// fmt.Printf("RENTER COINS AFTER: %v\n", renterCoinsAfter)
+	// Requested slot must be free in the asset calendar
+	if other, found := k.getOverlappingBooking(ctx, msg.UUID, msg.Start, msg.End); found {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_SLOT_TAKEN,
+			asset.UUID,
+			other.Start,
+			other.End)
+	}
 
-	if !renterCoinsAfter.IsNotNegative() {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE,
-			renter.GetAddress())
+	// Payment is held in escrow until the booking is completed,
+	// deposit until the claim window closes
+	quote, err := quoteAsset(asset, msg.Start, msg.End, msg.Duration)
+	if err != nil {
+		return types.Booking{}, err
 	}
 
+	payment := quote.Payment
+	deposit := quote.Deposit
+
 	booking := types.NewBooking(bookingId,
 		renter.GetAddress(),
 		msg.UUID,
 		msg.Duration,
+		msg.Start,
+		msg.End,
 		false)
+	booking.Payment = payment
+	booking.Deposit = deposit
+	booking.DepositClaimed = types.NewCoin(asset.PriceDenom(), 0)
+
+	// Terms of cancellation are fixed at booking, the owner may change the asset policy later
+	booking.RefundPolicy = asset.RefundPolicy
+
+	// Slot and funds are held either way. A request is released if the owner does not answer in time
+	if asset.RequiresApproval() {
+		setStatus(ctx, &booking, constants.BOOKING_STATUS_REQUESTED)
+		booking.RequestDeadline = ctx.BlockHeight() + constants.BOOKING_REQUEST_TIMEOUT
+	} else {
+		setStatus(ctx, &booking, constants.BOOKING_STATUS_CONFIRMED)
+	}
 
-	// Update asset status
-	asset.Status = false
+	// Renter may pay in another denom, converted to the asset price denom at the current rate
+	booking.Paid, err = k.convertPayment(ctx, renter.GetAddress(), payment.Plus(deposit), msg.MaxPayment)
+	if err != nil {
+		return types.Booking{}, err
+	}
+
+	// Booking on behalf of the renter spends from the grant of the signer
+	if err := k.bankKeeper.SpendGrant(ctx, booking.Paid); err != nil {
+		return types.Booking{}, err
+	}
 
 	err = utils.Store(bookingStore, []byte(booking.BookingID), booking)
 	if err != nil {
@@ -113,18 +183,24 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 			constants.STORE_BOOKING)
 	}
 
-	err = utils.Store(assetStore, []byte(asset.UUID), asset)
-	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
-			"types.Asset",
-			constants.STORE_ASSET)
+	// Reserve the slot in the asset calendar
+	k.setCalendarSlot(ctx, booking)
+	k.setIndexes(ctx, booking)
+
+	if booking.GetStatus() == constants.BOOKING_STATUS_REQUESTED {
+		k.setRequestQueue(ctx, booking)
 	}
 
-	// Save new balance
-	renterAcc.SetCoins(renterCoinsAfter)
+	// Move payment and deposit from renter to escrow
+	if err := k.holdInEscrow(ctx, renter.GetAddress(), payment); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE,
+			renter.GetAddress())
+	}
 
-	// save new account
-	k.accountMapper.SetAccount(ctx, renterAcc)
+	if err := k.holdInEscrow(ctx, renter.GetAddress(), deposit); err != nil {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE,
+			renter.GetAddress())
+	}
 
 	return booking, nil
 
@@ -133,94 +209,238 @@ func (k Keeper) Book(ctx sdk.Context, msg msg.MsgBook) (types.Booking, error) {
 func (k Keeper) Complete(ctx sdk.Context, msg msg.MsgComplete) (types.Booking, error) {
 
 	bookingStore := ctx.KVStore(k.bookingKey)
-	assetStore := ctx.KVStore(k.assetKey)
 	//accountStore := ctx.KVStore(k.accountKey)
 
 	// Checking booking
-	var booking types.Booking
-
-	err := utils.Retrieve(bookingStore, []byte(msg.BookingID), &booking)
-	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
-			"types.Booking",
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
 			constants.STORE_BOOKING)
 	}
 
-	// renter deduced from signature
-	renter := auth.GetSigner(ctx)
+	// Check asset
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
+			constants.STORE_ASSET)
+	}
+
+	setLegacyPayment(&booking, asset)
 
-	// only account initiate booking can complete it
-	if !bytes.Equal(renter.GetAddress(), booking.Renter) {
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	switch {
+	case bytes.Equal(signer, booking.Renter):
+		// the device of the asset has to attest the renter handed it back
+		if asset.HasDevice() && booking.CheckOutHeight == 0 {
+			return types.Booking{}, fmt.Errorf(constants.BOOKING_NOT_CHECKED_OUT,
+				booking.BookingID)
+		}
+	case bytes.Equal(signer, asset.Creator):
+		// owner override, whether the booking was checked out or not
+	default:
 		return types.Booking{}, fmt.Errorf(constants.BOOKING_MISMATCH_RENTER,
 			utils.ByteToString(booking.Renter),
-			utils.ByteToString(renter.GetAddress()))
+			utils.ByteToString(signer))
 	}
 
-	if booking.IsCompleted == true {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_COMPLETED_ERROR,
-			booking.BookingID)
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_COMPLETED); err != nil {
+		return types.Booking{}, err
 	}
 
-	// Check asset
-	var asset types.Asset
+	// Release only the slot of this booking. Other reservations of the asset are kept
+	k.removeCalendarSlot(ctx, booking)
+
+	if err := k.releaseLegacyAsset(ctx, booking, asset); err != nil {
+		return types.Booking{}, err
+	}
+
+	// Owner can claim the deposit until the claim window closes
+	if !booking.Deposit.IsNil() && booking.Deposit.IsPositive() {
+		booking.ClaimDeadline = ctx.BlockHeader().Time.Unix() + constants.DEPOSIT_CLAIM_WINDOW
+		k.setDepositQueue(ctx, booking)
+	}
+
+	// Save booking detail
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
 
-	err = utils.Retrieve(assetStore, []byte(booking.UUID), &asset)
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_RETRIEVAL,
-			"types.Asset",
-			constants.STORE_ASSET)
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
 	}
 
-	if asset.Status != false {
-		return types.Booking{}, fmt.Errorf(constants.BOOKING_ASSET_NOT_RENTED,
-			asset.UUID)
+	// Pay the owner from escrow
+	if err := k.releasePayment(ctx, booking, asset.Creator, booking.Payment); err != nil {
+		return types.Booking{}, err
 	}
 
-	// Checking owner account
-	ownerAccount := k.accountMapper.GetAccount(ctx, asset.Creator)
+	return booking, nil
+
+}
+
+// Cancel - cancel a booking on behalf of its renter or the asset owner.
+// The payment held in escrow is split between renter and owner following the
+// refund policy of the asset at booking. A renter cannot cancel a started booking
+// unless that policy is a partial refund. An owner cancelling, or a renter withdrawing
+// a request the owner has not answered, always refunds the renter in full.
+func (k Keeper) Cancel(ctx sdk.Context, msg msg.MsgCancelBooking) (types.Booking, types.Coin, error) {
 
-	if ownerAccount == nil {
-		ownerAccount = auth.NewSHRAccountWithAddress(asset.Creator)
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
 	}
 
-	ownerCoins := ownerAccount.GetCoins()
+	previous := booking.GetStatus()
+
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_CANCELLED); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
 
-	// Update owner balance
-	value := booking.Duration * asset.Fee
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
+			constants.STORE_ASSET)
+	}
 
-	ownerCoinsAfter := ownerCoins.Plus(types.NewCoin(constants.BOOKING_DENOM, value))
-	constants.LOGGER.Info("Owner balance", "balance", ownerCoinsAfter)
+	setLegacyPayment(&booking, asset)
+
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	var refund types.Coin
+
+	now := ctx.BlockHeader().Time.Unix()
+
+	switch {
+	case bytes.Equal(signer, booking.Renter) && previous == constants.BOOKING_STATUS_REQUESTED:
+		// the owner never accepted the booking, the renter withdraws the request
+		refund = booking.Payment
+	case bytes.Equal(signer, booking.Renter):
+		if now >= booking.Start && !booking.RefundPolicy.AllowsLateCancel() {
+			return types.Booking{}, types.Coin{}, fmt.Errorf(constants.BOOKING_CANCEL_STARTED,
+				booking.BookingID,
+				booking.Start)
+		}
+
+		refund = booking.RefundPolicy.Refund(booking.Payment,
+			booking.Start,
+			now)
+	case bytes.Equal(signer, asset.Creator):
+		refund = booking.Payment
+	default:
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.BOOKING_CANCEL_UNAUTHORIZED,
+			booking.BookingID,
+			utils.ByteToString(signer))
+	}
 
-	// Update Booking
-	booking.IsCompleted = true
+	// The asset is available again for this slot
+	k.removeCalendarSlot(ctx, booking)
 
-	// Update asset status. Asset is available now
-	asset.Status = true
+	if err := k.releaseLegacyAsset(ctx, booking, asset); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
 
-	// Save asset detail
-	err = utils.Store(assetStore, []byte(asset.UUID), asset)
+	if previous == constants.BOOKING_STATUS_REQUESTED {
+		k.removeRequestQueue(ctx, booking)
+	}
 
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
 	if err != nil {
-		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
-			"types.Asset",
+		return types.Booking{}, types.Coin{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
+	}
+
+	// Refund renter, the rest goes to the owner
+	if err := k.releasePayment(ctx, booking, booking.Renter, refund); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
+
+	// Nothing can be damaged by a cancelled booking, deposit goes back in full.
+	// Legacy bookings locked no deposit
+	if !booking.IsLegacy() {
+		if err := k.releaseFromEscrow(ctx, booking.Renter, booking.Deposit); err != nil {
+			return types.Booking{}, types.Coin{}, err
+		}
+	}
+
+	if err := k.releasePayment(ctx, booking, asset.Creator, booking.Payment.Minus(refund)); err != nil {
+		return types.Booking{}, types.Coin{}, err
+	}
+
+	return booking, refund, nil
+}
+
+// Confirm - owner of the asset accepts a requested booking, which becomes active
+func (k Keeper) Confirm(ctx sdk.Context, msg msg.MsgConfirmBooking) (types.Booking, error) {
+
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
+	}
+
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
 			constants.STORE_ASSET)
 	}
 
-	// Save booking detail
-	err = utils.Store(bookingStore, []byte(asset.UUID), booking)
+	signer := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(signer, asset.Creator) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_CONFIRM_UNAUTHORIZED,
+			booking.BookingID,
+			utils.ByteToString(signer))
+	}
+
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_CONFIRMED); err != nil {
+		return types.Booking{}, err
+	}
 
+	// Requests past their deadline are released at the end of the block
+	if ctx.BlockHeight() > booking.RequestDeadline {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_REQUEST_EXPIRED,
+			booking.BookingID,
+			booking.RequestDeadline)
+	}
+
+	k.removeRequestQueue(ctx, booking)
+
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
 	if err != nil {
 		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
 			"types.Booking",
 			constants.STORE_BOOKING)
 	}
 
-	// Save new balance to owner
-	ownerAccount.SetCoins(ownerCoinsAfter)
+	return booking, nil
+}
 
-	// Save account
-	k.accountMapper.SetAccount(ctx, ownerAccount)
+// releaseLegacyAsset makes the asset of an ending legacy booking available again.
+// Legacy bookings have no calendar slot, they held their asset by clearing its status.
+func (k Keeper) releaseLegacyAsset(ctx sdk.Context, booking types.Booking, asset types.Asset) error {
+	if !booking.IsLegacy() {
+		return nil
+	}
 
-	return booking, nil
+	asset.Status = true
 
+	err := utils.Store(ctx.KVStore(k.assetKey), []byte(asset.UUID), asset)
+	if err != nil {
+		return fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Asset",
+			constants.STORE_ASSET)
+	}
+	return nil
 }
@@ -0,0 +1,712 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/exchange"
+)
+
+const (
+	testNow  = int64(1000000)
+	testHour = int64(60 * 60)
+)
+
+var (
+	testOwner  = sdk.AccAddress([]byte("owner_______________"))
+	testRenter = sdk.AccAddress([]byte("renter______________"))
+)
+
+type testInput struct {
+	ctx sdk.Context
+	k   Keeper
+	bk  bank.Keeper
+	am  auth.AccountMapper
+	cdc *amino.Codec
+}
+
+func setupTestInput(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+	bookingKey := sdk.NewKVStoreKey(constants.STORE_BOOKING)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, assetKey, bookingKey, bankKey, exchangeKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	bk := bank.NewKeeper(am, bankKey, cdc)
+	ek := exchange.NewKeeper(exchangeKey, bk)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(testNow, 0)}, false, log.NewNopLogger())
+
+	return testInput{
+		ctx: ctx,
+		k:   NewKeeper(bookingKey, assetKey, bk, ek, cdc),
+		bk:  bk,
+		am:  am,
+		cdc: cdc,
+	}
+}
+
+// fund credits an account out of no other account
+func (in testInput) fund(t *testing.T, addr sdk.AccAddress, amt types.Coin) {
+	if _, err := in.bk.AddCoin(in.ctx, addr, amt); err != nil {
+		t.Fatalf("Funding %s failed. %s", addr, err)
+	}
+	in.bk.AddSupply(in.ctx, amt)
+}
+
+// signedBy returns the context of a transaction signed by *addr*.
+// Its nonce is increased as the ante handler does.
+func (in testInput) signedBy(addr sdk.AccAddress) sdk.Context {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		acc = auth.NewSHRAccountWithAddress(addr)
+	}
+	acc.SetNonce(acc.GetNonce() + 1)
+	in.am.SetAccount(in.ctx, acc)
+
+	return auth.WithSigners(in.ctx, acc)
+}
+
+func (in testInput) setAsset(t *testing.T, asset types.Asset) {
+	if err := utils.Store(in.ctx.KVStore(in.k.assetKey), []byte(asset.UUID), asset); err != nil {
+		t.Fatalf("Storing asset failed. %s", err)
+	}
+}
+
+func (in testInput) getAsset(t *testing.T, uuid string) types.Asset {
+	asset, found := in.k.GetAsset(in.ctx, uuid)
+	if !found {
+		t.Fatalf("Asset %s not found.", uuid)
+	}
+	return asset
+}
+
+func (in testInput) balance(addr sdk.AccAddress) types.Coin {
+	return in.bk.GetCoins(in.ctx, addr).GetCoin(constants.BOOKING_DENOM)
+}
+
+func (in testInput) checkInvariants(t *testing.T) {
+	if err := EscrowInvariant(in.ctx, in.k); err != nil {
+		t.Errorf("Escrow invariant broken. %s", err)
+	}
+	if err := in.bk.CheckSupply(in.ctx); err != nil {
+		t.Errorf("Supply invariant broken. %s", err)
+	}
+}
+
+// setLegacyBooking stores a booking as it was stored before time windows and escrow,
+// holding its asset through the asset status
+func (in testInput) setLegacyBooking(t *testing.T, bookingID string, uuid string, duration int64) {
+	asset := in.getAsset(t, uuid)
+	asset.Status = false
+	in.setAsset(t, asset)
+
+	booking := types.Booking{
+		BookingID: bookingID,
+		Renter:    testRenter,
+		UUID:      uuid,
+		Duration:  duration,
+	}
+	if err := utils.Store(in.ctx.KVStore(in.k.bookingKey), []byte(bookingID), booking); err != nil {
+		t.Fatalf("Storing booking failed. %s", err)
+	}
+}
+
+func TestLegacyBookingComplete(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.setLegacyBooking(t, "a1b2", "asset", 3)
+
+	// a legacy booking holds nothing in escrow
+	if _, _, err := in.k.Freeze(in.ctx, "a1b2", testOwner); err == nil {
+		t.Errorf("Freezing a legacy booking should fail.")
+	}
+
+	booking, err := in.k.Complete(in.signedBy(testRenter), msg.NewMsgComplete("a1b2"))
+	if err != nil {
+		t.Fatalf("Completing a legacy booking failed. %s", err)
+	}
+
+	if booking.GetStatus() != constants.BOOKING_STATUS_COMPLETED {
+		t.Errorf("Booking should be completed, got %s.", booking.GetStatus())
+	}
+
+	// owner is paid the duration at the asset fee, as before the escrow existed
+	if !in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 30)) {
+		t.Errorf("Owner should be paid 30, got %s.", in.balance(testOwner).String())
+	}
+
+	if !in.getAsset(t, "asset").Status {
+		t.Errorf("Asset should be available once its legacy booking is completed.")
+	}
+
+	in.checkInvariants(t)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	_, err = in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Errorf("Booking an asset released by a legacy booking failed. %s", err)
+	}
+}
+
+func TestLegacyBookingCancel(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.setLegacyBooking(t, "c3d4", "asset", 3)
+
+	_, refund, err := in.k.Cancel(in.signedBy(testOwner), msg.NewMsgCancelBooking("c3d4"))
+	if err != nil {
+		t.Fatalf("Cancelling a legacy booking failed. %s", err)
+	}
+
+	// an owner cancelling refunds the renter in full
+	if !refund.Equal(types.NewCoin(constants.BOOKING_DENOM, 30)) ||
+		!in.balance(testRenter).Equal(refund) {
+		t.Errorf("Renter should be refunded 30, got %s.", in.balance(testRenter).String())
+	}
+
+	if !in.getAsset(t, "asset").Status {
+		t.Errorf("Asset should be available once its legacy booking is cancelled.")
+	}
+
+	in.checkInvariants(t)
+}
+
+// setTime moves the block time of the test context
+func (in *testInput) setTime(now int64) {
+	header := in.ctx.BlockHeader()
+	header.Time = time.Unix(now, 0)
+	in.ctx = in.ctx.WithBlockHeader(header)
+}
+
+// setHeight moves the block height of the test context
+func (in *testInput) setHeight(height int64) {
+	in.ctx = in.ctx.WithBlockHeight(height)
+}
+
+func TestExpireRequests(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.BookingMode = constants.BOOKING_MODE_REQUEST
+	asset.Deposit = 5
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	// the owner can still answer at the deadline
+	in.setHeight(booking.RequestDeadline)
+	in.k.ExpireRequests(in.ctx)
+
+	if booking, _ := in.k.GetBooking(in.ctx, booking.BookingID); booking.GetStatus() != constants.BOOKING_STATUS_REQUESTED {
+		t.Errorf("Request should not expire at its deadline, got %s.", booking.GetStatus())
+	}
+
+	in.setHeight(booking.RequestDeadline + 1)
+
+	if _, err := in.k.Confirm(in.signedBy(testOwner), msg.NewMsgConfirmBooking(booking.BookingID)); err == nil {
+		t.Errorf("Confirming a request past its deadline should fail.")
+	}
+
+	in.k.ExpireRequests(in.ctx)
+
+	booking, _ = in.k.GetBooking(in.ctx, booking.BookingID)
+	if booking.GetStatus() != constants.BOOKING_STATUS_CANCELLED {
+		t.Errorf("Request should be cancelled past its deadline, got %s.", booking.GetStatus())
+	}
+
+	// the renter gets payment and deposit back, the slot is free again
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)) ||
+		!in.k.GetEscrow(in.ctx).GetCoin(constants.BOOKING_DENOM).IsZero() {
+		t.Errorf("Renter should be refunded in full, has %s.", in.balance(testRenter).String())
+	}
+
+	if in.k.HasActiveBooking(in.ctx, "asset") {
+		t.Errorf("Expired request should not hold the asset.")
+	}
+
+	// withdrawing a request refunds it in full whatever the refund policy
+	asset.RefundPolicy = types.NewRefundPolicy(constants.REFUND_NONE, 0)
+	in.setAsset(t, asset)
+
+	booking, err = in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	_, refund, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(booking.BookingID))
+	if err != nil {
+		t.Fatalf("Withdrawing a request failed. %s", err)
+	}
+
+	if !refund.Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) ||
+		!in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 100)) {
+		t.Errorf("Renter should be refunded in full, got %s.", refund.String())
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestEscrowBookCompleteCancel(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.Deposit = 5
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	escrow := func() types.Coin { return in.k.GetEscrow(in.ctx).GetCoin(constants.BOOKING_DENOM) }
+
+	first, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	// payment and deposit are held until the booking ends
+	if !escrow().Equal(types.NewCoin(constants.BOOKING_DENOM, 25)) ||
+		!in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 75)) {
+		t.Errorf("Escrow should hold 25 out of the renter, got %s.", escrow().String())
+	}
+	in.checkInvariants(t)
+
+	if _, err := in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(first.BookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+
+	// the owner is paid, the deposit stays for the claim window
+	if !escrow().Equal(types.NewCoin(constants.BOOKING_DENOM, 5)) ||
+		!in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Escrow should keep the deposit only, got %s.", escrow().String())
+	}
+	in.checkInvariants(t)
+
+	second, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	if _, _, err := in.k.Cancel(in.signedBy(testOwner), msg.NewMsgCancelBooking(second.BookingID)); err != nil {
+		t.Fatalf("Cancelling failed. %s", err)
+	}
+
+	// payment and deposit of the cancelled booking go back to the renter
+	if !escrow().Equal(types.NewCoin(constants.BOOKING_DENOM, 5)) ||
+		!in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 75)) {
+		t.Errorf("Renter should get 25 back, has %s.", in.balance(testRenter).String())
+	}
+	in.checkInvariants(t)
+}
+
+func TestBookingStatusTransitions(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.BookingMode = constants.BOOKING_MODE_REQUEST
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	if booking.GetStatus() != constants.BOOKING_STATUS_REQUESTED {
+		t.Errorf("Booking of an asset requiring approval should be requested, got %s.", booking.GetStatus())
+	}
+
+	if _, err := in.k.Confirm(in.signedBy(testRenter), msg.NewMsgConfirmBooking(booking.BookingID)); err == nil {
+		t.Errorf("Only the owner should confirm a request.")
+	}
+
+	booking, err = in.k.Confirm(in.signedBy(testOwner), msg.NewMsgConfirmBooking(booking.BookingID))
+	if err != nil {
+		t.Fatalf("Confirming failed. %s", err)
+	}
+	if booking.GetStatus() != constants.BOOKING_STATUS_CONFIRMED {
+		t.Errorf("Booking should be confirmed, got %s.", booking.GetStatus())
+	}
+
+	// a confirmed booking is no longer a request
+	if _, err := in.k.Confirm(in.signedBy(testOwner), msg.NewMsgConfirmBooking(booking.BookingID)); err == nil {
+		t.Errorf("Confirming a confirmed booking should fail.")
+	}
+	if _, err := in.k.Reject(in.signedBy(testOwner), msg.NewMsgRejectBooking(booking.BookingID)); err == nil {
+		t.Errorf("Rejecting a confirmed booking should fail.")
+	}
+
+	booking, err = in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(booking.BookingID))
+	if err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+	if booking.GetStatus() != constants.BOOKING_STATUS_COMPLETED || !booking.IsCompleted {
+		t.Errorf("Booking should be completed, got %s.", booking.GetStatus())
+	}
+
+	// completed is final but for disputes
+	if _, _, err := in.k.Cancel(in.signedBy(testOwner), msg.NewMsgCancelBooking(booking.BookingID)); err == nil {
+		t.Errorf("Cancelling a completed booking should fail.")
+	}
+	if _, err := in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(booking.BookingID)); err == nil {
+		t.Errorf("Completing a completed booking should fail.")
+	}
+
+	if len(booking.History) != 3 {
+		t.Errorf("History should record 3 statuses, got %d.", len(booking.History))
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestCancelRefundPolicy(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.RefundPolicy = types.NewRefundPolicy(constants.REFUND_FULL, 0)
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	first, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	// the owner changing the policy does not change the terms of existing bookings
+	asset.RefundPolicy = types.NewRefundPolicy(constants.REFUND_NONE, 0)
+	in.setAsset(t, asset)
+
+	_, refund, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(first.BookingID))
+	if err != nil {
+		t.Fatalf("Cancelling before the start failed. %s", err)
+	}
+	if !refund.Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Renter should be refunded in full, got %s.", refund.String())
+	}
+
+	asset.RefundPolicy = types.NewRefundPolicy(constants.REFUND_FULL, 0)
+	in.setAsset(t, asset)
+
+	second, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	// a started booking cannot be cancelled by its renter for a refund
+	in.setTime(testNow + 5*testHour)
+
+	if _, _, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(second.BookingID)); err == nil {
+		t.Errorf("Renter cancelling a started booking should fail.")
+	}
+
+	if _, _, err := in.k.Cancel(in.signedBy(testOwner), msg.NewMsgCancelBooking(second.BookingID)); err != nil {
+		t.Errorf("Owner cancelling a started booking failed. %s", err)
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestCancelStartedPartialRefund(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.RefundPolicy = types.NewRefundPolicy(constants.REFUND_PARTIAL, 50)
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	in.setTime(testNow + 2*testHour)
+
+	_, refund, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(booking.BookingID))
+	if err != nil {
+		t.Fatalf("Cancelling a started booking under a partial policy failed. %s", err)
+	}
+
+	// partial refunds are only granted before the start
+	if !refund.IsZero() || !in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Owner should keep the whole payment, renter got %s.", refund.String())
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestMigrateLegacyBookings(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.setLegacyBooking(t, "ab12", "asset", 3)
+
+	EndBlocker(in.ctx, in.k)
+
+	if !in.k.IsMigrated(in.ctx) {
+		t.Fatalf("Legacy booking IDs should be migrated at the first block.")
+	}
+
+	store := in.ctx.KVStore(in.k.bookingKey)
+	if store.Has([]byte("ab12")) {
+		t.Errorf("Booking should no longer be stored under its short ID.")
+	}
+
+	// the short ID resolves to the migrated booking
+	booking, found := in.k.GetBooking(in.ctx, "ab12")
+	if !found || booking.BookingID != legacyToFullID("ab12") {
+		t.Fatalf("Short ID should resolve to %s, got %v.", legacyToFullID("ab12"), booking)
+	}
+
+	if _, err := in.k.Complete(in.signedBy(testRenter), msg.NewMsgComplete("ab12")); err != nil {
+		t.Errorf("Completing a migrated booking by its short ID failed. %s", err)
+	}
+
+	// the migration runs once
+	if migrated := in.k.MigrateLegacyBookings(in.ctx); migrated != 0 {
+		t.Errorf("No booking should be left to migrate, migrated %d.", migrated)
+	}
+}
+
+func TestSubscriptionLapses(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.Metadata.PricingUnit = constants.PRICING_DAY
+	in.setAsset(t, asset)
+
+	// the first period and one more
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 25))
+
+	subscription, err := in.k.Subscribe(in.signedBy(testRenter),
+		msg.NewMsgSubscribe("asset", "day", types.NewCoin(constants.BOOKING_DENOM, 10)))
+	if err != nil {
+		t.Fatalf("Subscribing failed. %s", err)
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 15)) ||
+		!in.k.GetEscrow(in.ctx).GetCoin(constants.BOOKING_DENOM).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) {
+		t.Errorf("First period should be held at once, renter has %s.", in.balance(testRenter).String())
+	}
+	in.checkInvariants(t)
+
+	in.setTime(testNow + constants.SECONDS_PER_DAY)
+	in.k.ChargeSubscriptions(in.ctx)
+
+	// the first period has elapsed, the second is held
+	subscription, _ = in.k.GetSubscription(in.ctx, subscription.SubscriptionID)
+	if subscription.Status != constants.SUBSCRIPTION_STATUS_ACTIVE ||
+		!in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) {
+		t.Errorf("Second period should be charged, owner has %s.", in.balance(testOwner).String())
+	}
+	in.checkInvariants(t)
+
+	// the renter is left with 5, short of the third period
+	in.setTime(testNow + 2*constants.SECONDS_PER_DAY)
+	in.k.ChargeSubscriptions(in.ctx)
+
+	subscription, _ = in.k.GetSubscription(in.ctx, subscription.SubscriptionID)
+	if subscription.Status != constants.SUBSCRIPTION_STATUS_LAPSED {
+		t.Errorf("Subscription should lapse, got %s.", subscription.Status)
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 5)) {
+		t.Errorf("Lapsed period should not be charged, renter has %s.", in.balance(testRenter).String())
+	}
+
+	if !in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) {
+		t.Errorf("Owner should be paid the elapsed second period, has %s.", in.balance(testOwner).String())
+	}
+
+	if in.k.HasActiveBooking(in.ctx, "asset") {
+		t.Errorf("Lapsed subscription should not hold the asset.")
+	}
+
+	// nothing is charged once lapsed
+	in.setTime(testNow + 3*constants.SECONDS_PER_DAY)
+	in.k.ChargeSubscriptions(in.ctx)
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 5)) {
+		t.Errorf("Lapsed subscription should not be charged, renter has %s.", in.balance(testRenter).String())
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestCancelSubscription(t *testing.T) {
+	in := setupTestInput(t)
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	asset.Metadata.PricingUnit = constants.PRICING_DAY
+	in.setAsset(t, asset)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 20))
+
+	subscribe := func() types.Subscription {
+		subscription, err := in.k.Subscribe(in.signedBy(testRenter),
+			msg.NewMsgSubscribe("asset", "day", types.NewCoin(constants.BOOKING_DENOM, 10)))
+		if err != nil {
+			t.Fatalf("Subscribing failed. %s", err)
+		}
+		return subscription
+	}
+
+	// an owner ending the rental refunds the period held
+	subscription := subscribe()
+	if _, err := in.k.CancelSubscription(in.signedBy(testOwner), msg.NewMsgCancelSubscription(subscription.SubscriptionID)); err != nil {
+		t.Fatalf("Owner cancelling failed. %s", err)
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) || !in.balance(testOwner).IsZero() {
+		t.Errorf("Renter should get the period back, has %s.", in.balance(testRenter).String())
+	}
+	in.checkInvariants(t)
+
+	// a renter gives the period up
+	subscription = subscribe()
+	if _, err := in.k.CancelSubscription(in.signedBy(testRenter), msg.NewMsgCancelSubscription(subscription.SubscriptionID)); err != nil {
+		t.Fatalf("Renter cancelling failed. %s", err)
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) ||
+		!in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) {
+		t.Errorf("Owner should be paid the period, has %s.", in.balance(testOwner).String())
+	}
+	in.checkInvariants(t)
+
+	if _, err := in.k.CancelSubscription(in.signedBy(testRenter), msg.NewMsgCancelSubscription(subscription.SubscriptionID)); err == nil {
+		t.Errorf("Cancelling a cancelled subscription should fail.")
+	}
+}
+
+func TestBookTwoSlotsInOneTx(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	// messages of one transaction share signer, nonce and height
+	ctx := auth.WithIDSeq(in.signedBy(testRenter))
+
+	first, err := in.k.Book(ctx, msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking the first slot failed. %s", err)
+	}
+
+	second, err := in.k.Book(ctx, msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking the second slot failed. %s", err)
+	}
+
+	if first.BookingID == second.BookingID {
+		t.Fatalf("Bookings of one transaction should have distinct IDs, both are %s.", first.BookingID)
+	}
+
+	for _, id := range []string{first.BookingID, second.BookingID} {
+		if _, found := in.k.GetBooking(in.ctx, id); !found {
+			t.Errorf("Booking %s not found.", id)
+		}
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestBookDurationMatchesPeriod(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+
+	daily := types.NewAsset("daily", testOwner, nil, true, 10)
+	daily.Metadata.PricingUnit = constants.PRICING_DAY
+	in.setAsset(t, daily)
+
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 1000))
+
+	// a year held for the price of one unit
+	year := testNow + testHour + 365*constants.SECONDS_PER_DAY
+	if _, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 1, testNow+testHour, year)); err == nil {
+		t.Errorf("Booking for fewer units than the period covers should fail.")
+	}
+
+	// assets without a pricing unit are billed per started hour
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 3, testNow+testHour, testNow+3*testHour+1))
+	if err != nil {
+		t.Fatalf("Booking for every started hour failed. %s", err)
+	}
+	if !booking.Payment.Equal(types.NewCoin(constants.BOOKING_DENOM, 30)) {
+		t.Errorf("Booking should be paid 30, got %s.", booking.Payment.String())
+	}
+
+	if _, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("daily", 2, testNow+testHour, testNow+3*testHour)); err == nil {
+		t.Errorf("Booking a daily asset for more days than the period covers should fail.")
+	}
+
+	booking, err = in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("daily", 1, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking a daily asset for a day failed. %s", err)
+	}
+	if !booking.Payment.Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) {
+		t.Errorf("Booking should be paid 10, got %s.", booking.Payment.String())
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestSettleWithoutAsset(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	booking, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+
+	in.ctx.KVStore(in.k.assetKey).Delete([]byte("asset"))
+
+	if _, err := in.k.Complete(in.signedBy(testRenter), msg.NewMsgComplete(booking.BookingID)); err == nil {
+		t.Errorf("Completing a booking of a missing asset should fail.")
+	}
+
+	if _, _, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(booking.BookingID)); err == nil {
+		t.Errorf("Cancelling a booking of a missing asset should fail.")
+	}
+
+	if in.ctx.KVStore(in.k.assetKey).Has([]byte("")) {
+		t.Errorf("No asset should be stored under an empty UUID.")
+	}
+}
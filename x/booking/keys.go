@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Bookings themselves are stored under their BookingID, a hex string.
+// Indexes live under single byte prefixes which never start a BookingID.
+
+//nolint
+var (
+	// Keys for store prefixes
+	CalendarKey     = []byte{0x01} // prefix for each key to a booked slot, by asset and start time
+	DepositQueueKey = []byte{0x02} // prefix for each key to a deposit in its claim window, by deadline
+	ByRenterKey     = []byte{0x03} // prefix for each key to a booking, by renter
+	ByAssetKey      = []byte{0x04} // prefix for each key to a booking, by asset
+	LegacyIDKey     = []byte{0x05} // prefix for each key to a migrated legacy short ID
+	RequestQueueKey = []byte{0x06} // prefix for each key to a booking request, by answer deadline
+
+	SubscriptionKey        = []byte{0x07} // prefix for each key to a subscription
+	SubscriptionQueueKey   = []byte{0x08} // prefix for each key to an active subscription, by next charge time
+	SubscriptionByAssetKey = []byte{0x09} // prefix for each key to an active subscription, by asset
+
+	MigrationKey = []byte{0x0a} // key for the height legacy booking IDs were migrated at
+
+	BookingKeyStart = []byte{0x20} // lowest possible key of a booking, its ID is printable
+)
+
+// gets the prefix for all booked slots of an asset
+func GetCalendarPrefix(uuid string) []byte {
+	return append(CalendarKey, lengthPrefixed(uuid)...)
+}
+
+// gets the key for a booked slot of an asset
+// VALUE: BookingID
+func GetCalendarKey(uuid string, start int64, bookingID string) []byte {
+	return append(append(
+		GetCalendarPrefix(uuid),
+		int64ToBytes(start)...),
+		[]byte(bookingID)...)
+}
+
+// gets the prefix for all deposits whose claim window closes at deadline
+func GetDepositQueueTimeKey(deadline int64) []byte {
+	return append(DepositQueueKey, int64ToBytes(deadline)...)
+}
+
+// gets the key for a deposit in its claim window
+// VALUE: BookingID
+func GetDepositQueueKey(deadline int64, bookingID string) []byte {
+	return append(GetDepositQueueTimeKey(deadline), []byte(bookingID)...)
+}
+
+// gets the prefix for all booking requests whose answer deadline is height
+func GetRequestQueueHeightKey(height int64) []byte {
+	return append(RequestQueueKey, int64ToBytes(height)...)
+}
+
+// gets the key for a booking request waiting for an answer
+// VALUE: BookingID
+func GetRequestQueueKey(height int64, bookingID string) []byte {
+	return append(GetRequestQueueHeightKey(height), []byte(bookingID)...)
+}
+
+// gets the key for a subscription
+// VALUE: types.Subscription
+func GetSubscriptionKey(subscriptionID string) []byte {
+	return append(SubscriptionKey, []byte(subscriptionID)...)
+}
+
+// gets the prefix for all subscriptions charged at time
+func GetSubscriptionQueueTimeKey(time int64) []byte {
+	return append(SubscriptionQueueKey, int64ToBytes(time)...)
+}
+
+// gets the key for an active subscription waiting for its next charge
+// VALUE: SubscriptionID
+func GetSubscriptionQueueKey(time int64, subscriptionID string) []byte {
+	return append(GetSubscriptionQueueTimeKey(time), []byte(subscriptionID)...)
+}
+
+// gets the prefix for all active subscriptions of an asset
+func GetSubscriptionByAssetPrefix(uuid string) []byte {
+	return append(SubscriptionByAssetKey, lengthPrefixed(uuid)...)
+}
+
+// gets the key for an active subscription of an asset
+// VALUE: SubscriptionID
+func GetSubscriptionByAssetKey(uuid string, subscriptionID string) []byte {
+	return append(GetSubscriptionByAssetPrefix(uuid), []byte(subscriptionID)...)
+}
+
+// gets the prefix for all bookings of a renter
+func GetByRenterPrefix(renter sdk.AccAddress) []byte {
+	return append(ByRenterKey, renter.Bytes()...)
+}
+
+// gets the key for a booking of a renter
+// VALUE: BookingID
+func GetByRenterKey(renter sdk.AccAddress, bookingID string) []byte {
+	return append(GetByRenterPrefix(renter), []byte(bookingID)...)
+}
+
+// gets the prefix for all bookings of an asset
+func GetByAssetPrefix(uuid string) []byte {
+	return append(ByAssetKey, lengthPrefixed(uuid)...)
+}
+
+// gets the key for a booking of an asset
+// VALUE: BookingID
+func GetByAssetKey(uuid string, bookingID string) []byte {
+	return append(GetByAssetPrefix(uuid), []byte(bookingID)...)
+}
+
+// gets the key for a legacy short ID
+// VALUE: BookingID
+func GetLegacyIDKey(shortID string) []byte {
+	return append(LegacyIDKey, []byte(shortID)...)
+}
+
+//______________________________________________________________________________
+
+// prefix a string with its length so that keys of "ab" never shadow keys of "abc"
+func lengthPrefixed(s string) []byte {
+	bz := make([]byte, 2, 2+len(s))
+	binary.BigEndian.PutUint16(bz, uint16(len(s)))
+	return append(bz, []byte(s)...)
+}
+
+// big endian keeps lexicographic key order equal to numeric order for non-negative values
+func int64ToBytes(i int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(i))
+	return bz
+}
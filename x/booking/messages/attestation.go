@@ -0,0 +1,32 @@
+package messages
+
+import (
+	"encoding/json"
+)
+
+// Actions a device attests
+const (
+	ActionCheckIn  = "check_in"
+	ActionCheckOut = "check_out"
+)
+
+// DeviceAttestation - what the device of an asset signs to attest a handover
+type DeviceAttestation struct {
+	Action    string `json:"action"`
+	BookingID string `json:"bookingId"`
+	Height    int64  `json:"height"`
+}
+
+// DeviceSignBytes returns the bytes a device signs to attest *action* for a booking at block *height*
+func DeviceSignBytes(action string, bookingID string, height int64) []byte {
+	b, err := json.Marshal(DeviceAttestation{
+		Action:    action,
+		BookingID: bookingID,
+		Height:    height,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
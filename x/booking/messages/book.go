@@ -3,23 +3,33 @@ package messages
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
 	tags "github.com/sharering/shareledger/x/booking/tags"
 )
 
 type MsgBook struct {
 	UUID     string `json:"uuid"`
 	Duration int64  `json:"duration"`
+	Start    int64  `json:"start"` // unix time, inclusive
+	End      int64  `json:"end"`   // unix time, exclusive
+
+	// MaxPayment - most the renter pays for payment and deposit, in the denom it pays with.
+	// Empty to pay in the asset price denom without a bound.
+	MaxPayment types.Coin `json:"max_payment"`
 }
 
 var _ sdk.Msg = MsgBook{}
 
-func NewMsgBook(uuid string, duration int64) MsgBook {
+func NewMsgBook(uuid string, duration int64, start int64, end int64) MsgBook {
 	return MsgBook{
 		UUID:     uuid,
 		Duration: duration,
+		Start:    start,
+		End:      end,
 	}
 }
 
@@ -36,6 +46,23 @@ func (msg MsgBook) ValidateBasic() sdk.Error {
 	//return sdk.ErrInvalidAddress("Invalid address")
 	//}
 
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Asset UUID is empty")
+	}
+
+	if msg.Duration <= 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_DURATION, msg.Duration))
+	}
+
+	if msg.Start < 0 || msg.End <= msg.Start {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_PERIOD, msg.Start, msg.End))
+	}
+
+	if msg.MaxPayment.Denom != "" &&
+		(!types.IsValidDenom(msg.MaxPayment.Denom) || msg.MaxPayment.IsNil() || !msg.MaxPayment.IsNotNegative()) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_MAX_PAYMENT, msg.MaxPayment.String()))
+	}
+
 	return nil
 }
 
@@ -51,7 +78,7 @@ func (msg MsgBook) GetSignBytes() []byte {
 func (msg MsgBook) Get(key interface{}) (value interface{}) { return nil }
 
 func (msg MsgBook) String() string {
-	return fmt.Sprintf("Booking/MsgBook{UUID: %s}", msg.UUID)
+	return fmt.Sprintf("Booking/MsgBook{UUID: %s, Start: %d, End: %d}", msg.UUID, msg.Start, msg.End)
 }
 
 func (msg MsgBook) GetSigners() []sdk.AccAddress {
@@ -61,5 +88,7 @@ func (msg MsgBook) GetSigners() []sdk.AccAddress {
 
 func (msg MsgBook) Tags() sdk.Tags {
 	return sdk.NewTags(tags.Event, tags.BookingStarted).
-		AppendTag(tags.UUID, string(msg.UUID))
+		AppendTag(tags.UUID, string(msg.UUID)).
+		AppendTag(tags.Start, strconv.FormatInt(msg.Start, 10)).
+		AppendTag(tags.End, strconv.FormatInt(msg.End, 10))
 }
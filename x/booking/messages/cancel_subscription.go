@@ -0,0 +1,64 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgCancelSubscription - stop a subscription, signed by its renter or the asset owner.
+// The current period stays paid.
+type MsgCancelSubscription struct {
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+var _ sdk.Msg = MsgCancelSubscription{}
+
+func NewMsgCancelSubscription(subscriptionId string) MsgCancelSubscription {
+	return MsgCancelSubscription{
+		SubscriptionID: subscriptionId,
+	}
+}
+
+func (msg MsgCancelSubscription) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCancelSubscription) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCancelSubscription) ValidateBasic() sdk.Error {
+	if len(msg.SubscriptionID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid SubscriptionID")
+	}
+
+	return nil
+}
+
+func (msg MsgCancelSubscription) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgCancelSubscription) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgCancelSubscription) String() string {
+	return fmt.Sprintf("Booking/MsgCancelSubscription{SubscriptionID: %s}", msg.SubscriptionID)
+}
+
+func (msg MsgCancelSubscription) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgCancelSubscription) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.SubscriptionCancelled).
+		AppendTag(tags.SubscriptionId, msg.SubscriptionID)
+}
@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgCheckIn - renter takes over the asset, attested by the device of the asset
+type MsgCheckIn struct {
+	BookingID       string                   `json:"bookingId"`
+	Height          int64                    `json:"height"` // block the device signed at
+	DeviceSignature types.SignatureSecp256k1 `json:"device_signature"`
+}
+
+var _ sdk.Msg = MsgCheckIn{}
+
+func NewMsgCheckIn(bookingId string, height int64, sig types.SignatureSecp256k1) MsgCheckIn {
+	return MsgCheckIn{
+		BookingID:       bookingId,
+		Height:          height,
+		DeviceSignature: sig,
+	}
+}
+
+func (msg MsgCheckIn) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCheckIn) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCheckIn) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if msg.Height <= 0 || msg.DeviceSignature.IsZero() {
+		return sdk.ErrUnknownRequest("Device attestation is required")
+	}
+
+	return nil
+}
+
+// DeviceSignBytes - bytes the device of the asset signed
+func (msg MsgCheckIn) DeviceSignBytes() []byte {
+	return DeviceSignBytes(ActionCheckIn, msg.BookingID, msg.Height)
+}
+
+func (msg MsgCheckIn) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgCheckIn) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgCheckIn) String() string {
+	return fmt.Sprintf("Booking/MsgCheckIn{BookingID: %s, Height: %d}", msg.BookingID, msg.Height)
+}
+
+func (msg MsgCheckIn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgCheckIn) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingCheckedIn).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.Height, strconv.FormatInt(msg.Height, 10))
+}
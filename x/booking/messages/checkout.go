@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgCheckOut - renter hands the asset back, attested by the device of the asset
+type MsgCheckOut struct {
+	BookingID       string                   `json:"bookingId"`
+	Height          int64                    `json:"height"` // block the device signed at
+	DeviceSignature types.SignatureSecp256k1 `json:"device_signature"`
+}
+
+var _ sdk.Msg = MsgCheckOut{}
+
+func NewMsgCheckOut(bookingId string, height int64, sig types.SignatureSecp256k1) MsgCheckOut {
+	return MsgCheckOut{
+		BookingID:       bookingId,
+		Height:          height,
+		DeviceSignature: sig,
+	}
+}
+
+func (msg MsgCheckOut) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCheckOut) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgCheckOut) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if msg.Height <= 0 || msg.DeviceSignature.IsZero() {
+		return sdk.ErrUnknownRequest("Device attestation is required")
+	}
+
+	return nil
+}
+
+// DeviceSignBytes - bytes the device of the asset signed
+func (msg MsgCheckOut) DeviceSignBytes() []byte {
+	return DeviceSignBytes(ActionCheckOut, msg.BookingID, msg.Height)
+}
+
+func (msg MsgCheckOut) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgCheckOut) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgCheckOut) String() string {
+	return fmt.Sprintf("Booking/MsgCheckOut{BookingID: %s, Height: %d}", msg.BookingID, msg.Height)
+}
+
+func (msg MsgCheckOut) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgCheckOut) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingCheckedOut).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.Height, strconv.FormatInt(msg.Height, 10))
+}
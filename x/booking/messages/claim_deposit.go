@@ -0,0 +1,79 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgClaimDeposit - asset owner claims part of the deposit of a completed booking
+type MsgClaimDeposit struct {
+	BookingID string `json:"bookingId"`
+	Amount    int64  `json:"amount"`
+	Evidence  []byte `json:"evidence"` // hash of the off-chain evidence of damages
+}
+
+var _ sdk.Msg = MsgClaimDeposit{}
+
+func NewMsgClaimDeposit(bookingId string, amount int64, evidence []byte) MsgClaimDeposit {
+	return MsgClaimDeposit{
+		BookingID: bookingId,
+		Amount:    amount,
+		Evidence:  evidence,
+	}
+}
+
+func (msg MsgClaimDeposit) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgClaimDeposit) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgClaimDeposit) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if msg.Amount <= 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_CLAIM, msg.Amount))
+	}
+
+	if len(msg.Evidence) == 0 {
+		return sdk.ErrUnknownRequest("Evidence hash is required")
+	}
+
+	return nil
+}
+
+func (msg MsgClaimDeposit) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgClaimDeposit) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgClaimDeposit) String() string {
+	return fmt.Sprintf("Booking/MsgClaimDeposit{BookingID: %s, Amount: %d, Evidence: %X}",
+		msg.BookingID, msg.Amount, msg.Evidence)
+}
+
+func (msg MsgClaimDeposit) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgClaimDeposit) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DepositClaimed).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.Amount, strconv.FormatInt(msg.Amount, 10)).
+		AppendTag(tags.Evidence, fmt.Sprintf("%X", msg.Evidence))
+}
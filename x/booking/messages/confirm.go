@@ -0,0 +1,63 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgConfirmBooking - approve a requested booking, signed by the asset owner
+type MsgConfirmBooking struct {
+	BookingID string `json:"bookingId"`
+}
+
+var _ sdk.Msg = MsgConfirmBooking{}
+
+func NewMsgConfirmBooking(bookingId string) MsgConfirmBooking {
+	return MsgConfirmBooking{
+		BookingID: bookingId,
+	}
+}
+
+func (msg MsgConfirmBooking) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgConfirmBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgConfirmBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	return nil
+}
+
+func (msg MsgConfirmBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgConfirmBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgConfirmBooking) String() string {
+	return fmt.Sprintf("Booking/MsgConfirmBooking{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgConfirmBooking) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgConfirmBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingConfirmed).
+		AppendTag(tags.BookingId, msg.BookingID)
+}
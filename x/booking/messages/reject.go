@@ -0,0 +1,63 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgRejectBooking - refuse a requested booking, signed by the asset owner
+type MsgRejectBooking struct {
+	BookingID string `json:"bookingId"`
+}
+
+var _ sdk.Msg = MsgRejectBooking{}
+
+func NewMsgRejectBooking(bookingId string) MsgRejectBooking {
+	return MsgRejectBooking{
+		BookingID: bookingId,
+	}
+}
+
+func (msg MsgRejectBooking) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgRejectBooking) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgRejectBooking) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	return nil
+}
+
+func (msg MsgRejectBooking) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgRejectBooking) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgRejectBooking) String() string {
+	return fmt.Sprintf("Booking/MsgRejectBooking{BookingID: %s}", msg.BookingID)
+}
+
+func (msg MsgRejectBooking) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgRejectBooking) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.BookingRejected).
+		AppendTag(tags.BookingId, msg.BookingID)
+}
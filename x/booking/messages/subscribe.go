@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	tags "github.com/sharering/shareledger/x/booking/tags"
+)
+
+// MsgSubscribe - renter authorizes a recurring charge to rent an asset every period
+type MsgSubscribe struct {
+	UUID      string     `json:"uuid"`
+	Period    string     `json:"period"`     // one of SUBSCRIPTION_PERIODS
+	MaxAmount types.Coin `json:"max_amount"` // most the renter is charged per period
+}
+
+var _ sdk.Msg = MsgSubscribe{}
+
+func NewMsgSubscribe(uuid string, period string, maxAmount types.Coin) MsgSubscribe {
+	return MsgSubscribe{
+		UUID:      uuid,
+		Period:    period,
+		MaxAmount: maxAmount,
+	}
+}
+
+func (msg MsgSubscribe) Route() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgSubscribe) Type() string {
+	return constants.MESSAGE_BOOKING
+}
+
+func (msg MsgSubscribe) ValidateBasic() sdk.Error {
+	if len(msg.UUID) == 0 {
+		return sdk.ErrUnknownRequest("Asset UUID is empty")
+	}
+
+	if _, ok := constants.SUBSCRIPTION_PERIODS[msg.Period]; !ok {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.SUBSCRIPTION_INVALID_PERIOD, msg.Period))
+	}
+
+	if !types.IsValidDenom(msg.MaxAmount.Denom) || msg.MaxAmount.IsNil() || !msg.MaxAmount.IsPositive() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.SUBSCRIPTION_INVALID_MAX_AMOUNT, msg.MaxAmount.String()))
+	}
+
+	return nil
+}
+
+func (msg MsgSubscribe) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgSubscribe) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgSubscribe) String() string {
+	return fmt.Sprintf("Booking/MsgSubscribe{UUID: %s, Period: %s, MaxAmount: %s}",
+		msg.UUID, msg.Period, msg.MaxAmount.String())
+}
+
+func (msg MsgSubscribe) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgSubscribe) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.SubscriptionStarted).
+		AppendTag(tags.UUID, msg.UUID).
+		AppendTag(tags.Period, msg.Period)
+}
@@ -0,0 +1,80 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/sharering/shareledger/types"
+)
+
+func TestPricingDiscountFor(t *testing.T) {
+	rules := types.NewPricingRules(0, 0, []types.DurationTier{
+		{MinDuration: 30, Discount: 20},
+		{MinDuration: 7, Discount: 10},
+	}, nil)
+
+	table := []struct {
+		duration int64
+		expected int64
+	}{
+		{1, 0},
+		{7, 10},
+		{29, 10},
+		{30, 20},
+		{365, 20},
+	}
+
+	for _, tc := range table {
+		ret := rules.DiscountFor(tc.duration)
+		if ret != tc.expected {
+			t.Errorf("DiscountFor(%d) should return %d but %d returned.", tc.duration, tc.expected, ret)
+		}
+	}
+}
+
+func TestPricingSurchargeAt(t *testing.T) {
+	// Saturday and Sunday, with a higher surcharge on Sunday evening
+	rules := types.NewPricingRules(0, 0, nil, []types.Surcharge{
+		{From: 5 * 86400, To: 7 * 86400, Percent: 25},
+		{From: 6*86400 + 18*3600, To: 7 * 86400, Percent: 50},
+	})
+
+	table := []struct {
+		time     int64
+		expected int64
+	}{
+		{0, 0},                  // Thursday 1970-01-01 00:00 UTC
+		{2 * 86400, 25},         // Saturday 00:00
+		{3*86400 + 17*3600, 25}, // Sunday 17:00
+		{3*86400 + 18*3600, 50}, // Sunday 18:00
+		{4 * 86400, 0},          // Monday 00:00
+		{-4 * 86400, 25},        // Sunday 1969-12-28 00:00
+	}
+
+	for _, tc := range table {
+		ret := rules.SurchargeAt(tc.time)
+		if ret != tc.expected {
+			t.Errorf("SurchargeAt(%d) should return %d but %d returned.", tc.time, tc.expected, ret)
+		}
+	}
+}
+
+func TestPricingValidate(t *testing.T) {
+	table := []struct {
+		rules types.PricingRules
+		valid bool
+	}{
+		{types.PricingRules{}, true},
+		{types.NewPricingRules(2, 10, nil, nil), true},
+		{types.NewPricingRules(10, 2, nil, nil), false},
+		{types.NewPricingRules(0, 0, []types.DurationTier{{MinDuration: 7, Discount: 101}}, nil), false},
+		{types.NewPricingRules(0, 0, nil, []types.Surcharge{{From: 10, To: 10, Percent: 5}}), false},
+		{types.NewPricingRules(0, 0, nil, []types.Surcharge{{From: 0, To: 8 * 86400, Percent: 5}}), false},
+	}
+
+	for _, tc := range table {
+		err := tc.rules.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("Validate(%+v) should be valid: %t, got %v.", tc.rules, tc.valid, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package booking
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// query endpoints supported by booking querier
+const (
+	QueryBooking  = "booking"
+	QueryByRenter = "by-renter"
+	QueryByAsset  = "by-asset"
+	QueryEscrow   = "escrow"
+	QueryQuote    = "quote"
+
+	QuerySubscription = "subscription"
+)
+
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryBooking:
+			return queryBooking(ctx, cdc, req, k)
+		case QueryByRenter:
+			return queryByRenter(ctx, cdc, req, k)
+		case QueryByAsset:
+			return queryByAsset(ctx, cdc, req, k)
+		case QueryEscrow:
+			return queryEscrow(ctx, k)
+		case QueryQuote:
+			return queryQuote(ctx, cdc, req, k)
+		case QuerySubscription:
+			return querySubscription(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown booking query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/booking'
+type QueryBookingParams struct {
+	BookingID string
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/by-renter'
+type QueryByRenterParams struct {
+	Renter sdk.AccAddress
+	Status string // "active", "completed" or empty for all
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/by-asset'
+type QueryByAssetParams struct {
+	UUID   string
+	Status string // "active", "completed" or empty for all
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/quote'
+type QueryQuoteParams struct {
+	UUID     string
+	Start    int64
+	End      int64
+	Duration int64
+}
+
+// defines the params for the following queries:
+// - 'custom/booking/subscription'
+type QuerySubscriptionParams struct {
+	SubscriptionID string
+}
+
+func queryBooking(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryBookingParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryBookingParams"))
+	}
+
+	booking, found := k.GetBooking(ctx, params.BookingID)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND,
+			params.BookingID,
+			constants.STORE_BOOKING))
+	}
+
+	res, errRes = cdc.MarshalJSON(booking)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "types.Booking"))
+	}
+	return res, nil
+}
+
+func queryByRenter(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryByRenterParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("Malform address: %s", errRes.Error()))
+	}
+
+	if !isValidFilter(params.Status) {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_FILTER, params.Status))
+	}
+
+	bookings := k.GetBookingsByRenter(ctx, params.Renter, params.Status)
+
+	res, errRes = cdc.MarshalJSON(bookings)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Booking"))
+	}
+	return res, nil
+}
+
+func queryByAsset(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryByAssetParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryByAssetParams"))
+	}
+
+	if !isValidFilter(params.Status) {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.BOOKING_INVALID_FILTER, params.Status))
+	}
+
+	bookings := k.GetBookingsByAsset(ctx, params.UUID, params.Status)
+
+	res, errRes = cdc.MarshalJSON(bookings)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Booking"))
+	}
+	return res, nil
+}
+
+func isValidFilter(status string) bool {
+	switch status {
+	case "",
+		constants.BOOKING_FILTER_ACTIVE,
+		constants.BOOKING_STATUS_REQUESTED,
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_CHECKED_IN,
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_CANCELLED,
+		constants.BOOKING_STATUS_DISPUTED:
+		return true
+	}
+	return false
+}
+
+// EscrowBalance - result of 'custom/booking/escrow'
+type EscrowBalance struct {
+	Address sdk.AccAddress `json:"address"`
+	Held    types.Coins    `json:"held"` // balance of the escrow account
+	Owed    types.Coins    `json:"owed"` // sum of payments of open bookings
+}
+
+func queryEscrow(ctx sdk.Context, k Keeper) (res []byte, err sdk.Error) {
+	escrow := EscrowBalance{
+		Address: EscrowAddress,
+		Held:    k.GetEscrow(ctx),
+		Owed:    k.GetEscrowOwed(ctx),
+	}
+
+	res, errRes := json.Marshal(escrow)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "EscrowBalance"))
+	}
+	return res, nil
+}
+
+func queryQuote(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryQuoteParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryQuoteParams"))
+	}
+
+	quote, errRes := k.Quote(ctx, params.UUID, params.Start, params.End, params.Duration)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(errRes.Error())
+	}
+
+	res, errRes = cdc.MarshalJSON(quote)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "Quote"))
+	}
+	return res, nil
+}
+
+func querySubscription(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QuerySubscriptionParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QuerySubscriptionParams"))
+	}
+
+	subscription, found := k.GetSubscription(ctx, params.SubscriptionID)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND,
+			params.SubscriptionID,
+			constants.STORE_BOOKING))
+	}
+
+	res, errRes = cdc.MarshalJSON(subscription)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "types.Subscription"))
+	}
+	return res, nil
+}
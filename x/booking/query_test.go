@@ -0,0 +1,118 @@
+package booking
+
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+)
+
+// query runs the booking querier on *endpoint* with amino encoded *params*
+func (in testInput) query(t *testing.T, endpoint string, params interface{}, res interface{}) error {
+	querier := NewQuerier(in.k, in.cdc)
+
+	bz, err := querier(in.ctx, []string{endpoint}, abci.RequestQuery{Data: in.cdc.MustMarshalBinaryLengthPrefixed(params)})
+	if err != nil {
+		return err
+	}
+
+	if err := in.cdc.UnmarshalJSON(bz, res); err != nil {
+		t.Fatalf("Decoding %s result failed. %s", endpoint, err)
+	}
+	return nil
+}
+
+func TestQueryBookings(t *testing.T) {
+	in := setupTestInput(t)
+	in.setAsset(t, types.NewAsset("asset", testOwner, nil, true, 10))
+	in.fund(t, testRenter, types.NewCoin(constants.BOOKING_DENOM, 100))
+
+	first, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	second, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+4*testHour, testNow+6*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	third, err := in.k.Book(in.signedBy(testRenter), msg.NewMsgBook("asset", 2, testNow+7*testHour, testNow+9*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	if _, err := in.k.Complete(in.signedBy(testOwner), msg.NewMsgComplete(first.BookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+	if _, _, err := in.k.Cancel(in.signedBy(testRenter), msg.NewMsgCancelBooking(third.BookingID)); err != nil {
+		t.Fatalf("Cancelling failed. %s", err)
+	}
+
+	var booking types.Booking
+	if err := in.query(t, QueryBooking, QueryBookingParams{BookingID: second.BookingID}, &booking); err != nil {
+		t.Fatalf("Querying booking failed. %s", err)
+	}
+	if booking.BookingID != second.BookingID || booking.Start != second.Start {
+		t.Errorf("Query should return booking %s, got %+v.", second.BookingID, booking)
+	}
+
+	if err := in.query(t, QueryBooking, QueryBookingParams{BookingID: "missing"}, &booking); err == nil {
+		t.Errorf("Querying a missing booking should fail.")
+	}
+
+	table := []struct {
+		status   string
+		expected []string
+	}{
+		{"", []string{first.BookingID, second.BookingID, third.BookingID}},
+		{constants.BOOKING_FILTER_ACTIVE, []string{second.BookingID}},
+		{constants.BOOKING_STATUS_COMPLETED, []string{first.BookingID}},
+		{constants.BOOKING_STATUS_CANCELLED, []string{third.BookingID}},
+	}
+
+	for _, tc := range table {
+		var byRenter, byAsset []types.Booking
+
+		if err := in.query(t, QueryByRenter, QueryByRenterParams{Renter: testRenter, Status: tc.status}, &byRenter); err != nil {
+			t.Fatalf("Querying by renter with filter %q failed. %s", tc.status, err)
+		}
+		if err := in.query(t, QueryByAsset, QueryByAssetParams{UUID: "asset", Status: tc.status}, &byAsset); err != nil {
+			t.Fatalf("Querying by asset with filter %q failed. %s", tc.status, err)
+		}
+
+		for _, bookings := range [][]types.Booking{byRenter, byAsset} {
+			if !sameBookings(bookings, tc.expected) {
+				t.Errorf("Filter %q should return %v, got %d bookings.", tc.status, tc.expected, len(bookings))
+			}
+		}
+	}
+
+	var bookings []types.Booking
+	if err := in.query(t, QueryByAsset, QueryByAssetParams{UUID: "asset", Status: "lost"}, &bookings); err == nil {
+		t.Errorf("Querying with an unknown status filter should fail.")
+	}
+
+	if err := in.query(t, QueryByRenter, QueryByRenterParams{Renter: testOwner}, &bookings); err != nil || len(bookings) != 0 {
+		t.Errorf("Owner should have no bookings as a renter, got %d. %v", len(bookings), err)
+	}
+}
+
+// sameBookings - whether *bookings* have the expected IDs, in any order
+func sameBookings(bookings []types.Booking, expected []string) bool {
+	if len(bookings) != len(expected) {
+		return false
+	}
+
+	ids := map[string]bool{}
+	for _, booking := range bookings {
+		ids[booking.BookingID] = true
+	}
+
+	for _, id := range expected {
+		if !ids[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// Quote - price of a booking, as charged by MsgBook. Result of 'custom/booking/quote'
+type Quote struct {
+	UUID      string     `json:"uuid"`
+	Start     int64      `json:"start"`
+	End       int64      `json:"end"`
+	Duration  int64      `json:"duration"`
+	Base      types.Coin `json:"base"`      // Fee * Duration
+	Surcharge types.Coin `json:"surcharge"` // added for units in surcharged times of the week
+	Discount  types.Coin `json:"discount"`  // taken off base and surcharge for long bookings
+	Payment   types.Coin `json:"payment"`   // Base + Surcharge - Discount
+	Deposit   types.Coin `json:"deposit"`
+	Total     types.Coin `json:"total"` // Payment + Deposit
+}
+
+// Quote - price of booking asset *uuid* for *duration* units over [start, end).
+// It only depends on the asset, so clients get the same price the chain charges.
+func (k Keeper) Quote(ctx sdk.Context, uuid string, start int64, end int64, duration int64) (Quote, error) {
+	asset, found := k.GetAsset(ctx, uuid)
+	if !found {
+		return Quote{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			uuid,
+			constants.STORE_ASSET)
+	}
+
+	return quoteAsset(asset, start, end, duration)
+}
+
+// quoteAsset applies the pricing rules of an asset to a booking
+func quoteAsset(asset types.Asset, start int64, end int64, duration int64) (Quote, error) {
+	rules := asset.PricingRules
+	denom := asset.PriceDenom()
+
+	// Bookings are billed for every started unit of the period
+	if units := asset.BillingUnits(start, end); units != duration {
+		return Quote{}, fmt.Errorf(constants.BOOKING_DURATION_MISMATCH,
+			duration,
+			units,
+			asset.PricingUnit(),
+			start,
+			end)
+	}
+
+	if !rules.AllowsDuration(duration) {
+		return Quote{}, fmt.Errorf(constants.BOOKING_DURATION_NOT_ALLOWED,
+			duration,
+			rules.MinDuration,
+			rules.MaxDuration,
+			asset.UUID)
+	}
+
+	unitPrice := types.NewCoin(denom, asset.Fee)
+	base := types.NewCoin(denom, duration*asset.Fee)
+	surcharge := types.NewCoin(denom, 0)
+
+	if len(rules.Surcharges) > 0 {
+		unit := constants.PRICING_UNITS[asset.PricingUnit()]
+
+		if duration > constants.PRICING_MAX_SURCHARGED_UNITS {
+			return Quote{}, fmt.Errorf(constants.BOOKING_QUOTE_TOO_LONG,
+				duration,
+				constants.PRICING_MAX_SURCHARGED_UNITS)
+		}
+
+		for i := int64(0); i < duration; i++ {
+			if percent := rules.SurchargeAt(start + i*unit); percent > 0 {
+				surcharge = surcharge.Plus(unitPrice.Mul(types.NewDecWithPrec(percent, 2)))
+			}
+		}
+	}
+
+	subtotal := base.Plus(surcharge)
+	discount := subtotal.Mul(types.NewDecWithPrec(rules.DiscountFor(duration), 2))
+	payment := subtotal.Minus(discount)
+
+	// Deposit is locked for the booking and returned after the claim window
+	deposit := types.NewCoin(denom, asset.Deposit)
+
+	return Quote{
+		UUID:      asset.UUID,
+		Start:     start,
+		End:       end,
+		Duration:  duration,
+		Base:      base,
+		Surcharge: surcharge,
+		Discount:  discount,
+		Payment:   payment,
+		Deposit:   deposit,
+		Total:     payment.Plus(deposit),
+	}, nil
+}
@@ -0,0 +1,124 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/booking/tags"
+)
+
+// Reject - owner of the asset refuses a requested booking. The renter gets everything back.
+func (k Keeper) Reject(ctx sdk.Context, msg msg.MsgRejectBooking) (types.Booking, error) {
+
+	booking, found := k.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
+	}
+
+	asset, found := k.GetAsset(ctx, booking.UUID)
+	if !found {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			booking.UUID,
+			constants.STORE_ASSET)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+	if !bytes.Equal(signer, asset.Creator) {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_REJECT_UNAUTHORIZED,
+			booking.BookingID,
+			utils.ByteToString(signer))
+	}
+
+	if booking.GetStatus() == constants.BOOKING_STATUS_REQUESTED &&
+		ctx.BlockHeight() > booking.RequestDeadline {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_REQUEST_EXPIRED,
+			booking.BookingID,
+			booking.RequestDeadline)
+	}
+
+	return k.releaseRequest(ctx, booking)
+}
+
+// ExpireRequests - release booking requests the owner did not answer before their deadline
+func (k Keeper) ExpireRequests(ctx sdk.Context) sdk.Tags {
+	store := ctx.KVStore(k.bookingKey)
+
+	// collect first, the queue is modified while releasing requests
+	var expired []types.Booking
+
+	// requests can still be answered at their deadline block
+	iterator := store.Iterator(RequestQueueKey, GetRequestQueueHeightKey(ctx.BlockHeight()))
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, k.mustGetBooking(ctx, string(iterator.Value())))
+	}
+	iterator.Close()
+
+	resTags := sdk.EmptyTags()
+
+	for _, booking := range expired {
+		booking, err := k.releaseRequest(ctx, booking)
+		if err != nil {
+			panic(err)
+		}
+
+		resTags = resTags.
+			AppendTag(tags.Event, tags.BookingExpired).
+			AppendTag(tags.BookingId, booking.BookingID).
+			AppendTag(tags.Refund, booking.Payment.Plus(booking.Deposit).String())
+	}
+
+	return resTags
+}
+
+// releaseRequest cancels a requested booking and returns payment and deposit to the renter
+func (k Keeper) releaseRequest(ctx sdk.Context, booking types.Booking) (types.Booking, error) {
+	bookingStore := ctx.KVStore(k.bookingKey)
+
+	if booking.GetStatus() != constants.BOOKING_STATUS_REQUESTED {
+		return types.Booking{}, fmt.Errorf(constants.BOOKING_INVALID_TRANSITION,
+			booking.BookingID,
+			booking.GetStatus(),
+			constants.BOOKING_STATUS_CANCELLED)
+	}
+
+	if err := transition(ctx, &booking, constants.BOOKING_STATUS_CANCELLED); err != nil {
+		return types.Booking{}, err
+	}
+
+	k.removeCalendarSlot(ctx, booking)
+	k.removeRequestQueue(ctx, booking)
+
+	err := utils.Store(bookingStore, []byte(booking.BookingID), booking)
+	if err != nil {
+		return types.Booking{}, fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Booking",
+			constants.STORE_BOOKING)
+	}
+
+	if err := k.releaseFromEscrow(ctx, booking.Renter, booking.Payment.Plus(booking.Deposit)); err != nil {
+		return types.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// setRequestQueue puts a booking request in the queue of requests waiting for an answer
+func (k Keeper) setRequestQueue(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Set(GetRequestQueueKey(booking.RequestDeadline, booking.BookingID), []byte(booking.BookingID))
+}
+
+// removeRequestQueue takes an answered booking request out of the queue
+func (k Keeper) removeRequestQueue(ctx sdk.Context, booking types.Booking) {
+	store := ctx.KVStore(k.bookingKey)
+	store.Delete(GetRequestQueueKey(booking.RequestDeadline, booking.BookingID))
+}
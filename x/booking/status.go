@@ -0,0 +1,73 @@
+package booking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// transitions lists the statuses a booking can go to from each status.
+// Cancelled and disputed bookings are final.
+var transitions = map[string][]string{
+	constants.BOOKING_STATUS_REQUESTED: {
+		constants.BOOKING_STATUS_CONFIRMED,
+		constants.BOOKING_STATUS_CANCELLED,
+	},
+	constants.BOOKING_STATUS_CONFIRMED: {
+		constants.BOOKING_STATUS_CHECKED_IN,
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_CANCELLED,
+		constants.BOOKING_STATUS_DISPUTED,
+	},
+	constants.BOOKING_STATUS_CHECKED_IN: {
+		constants.BOOKING_STATUS_COMPLETED,
+		constants.BOOKING_STATUS_DISPUTED,
+	},
+	// the deposit stays disputable until its claim window closes
+	constants.BOOKING_STATUS_COMPLETED: {
+		constants.BOOKING_STATUS_DISPUTED,
+	},
+}
+
+// canTransition - whether a booking in status *from* can go to status *to*
+func canTransition(from string, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition moves a booking to status *to* and records the change at the current block height.
+// The booking is only modified in memory, callers store it.
+func transition(ctx sdk.Context, booking *types.Booking, to string) error {
+	from := booking.GetStatus()
+
+	if !canTransition(from, to) {
+		return fmt.Errorf(constants.BOOKING_INVALID_TRANSITION,
+			booking.BookingID,
+			from,
+			to)
+	}
+
+	setStatus(ctx, booking, to)
+	return nil
+}
+
+// setStatus records a status change without validating it, used for the first status of a booking
+func setStatus(ctx sdk.Context, booking *types.Booking, status string) {
+	booking.Status = status
+	booking.History = append(booking.History, types.BookingStatusChange{
+		Status: status,
+		Height: ctx.BlockHeight(),
+	})
+
+	// a completed booking stays completed for older clients, even once disputed
+	if status == constants.BOOKING_STATUS_COMPLETED {
+		booking.IsCompleted = true
+	}
+}
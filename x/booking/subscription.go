@@ -0,0 +1,325 @@
+package booking
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/booking/tags"
+)
+
+// GetSubscription returns the subscription stored under subscriptionID
+func (k Keeper) GetSubscription(ctx sdk.Context, subscriptionID string) (types.Subscription, bool) {
+	store := ctx.KVStore(k.bookingKey)
+
+	var subscription types.Subscription
+
+	err := utils.Retrieve(store, GetSubscriptionKey(subscriptionID), &subscription)
+	if err != nil || subscription.SubscriptionID == "" {
+		return types.Subscription{}, false
+	}
+	return subscription, true
+}
+
+// Subscribe - renter rents an asset period after period. The first period is charged right away,
+// the following ones by the EndBlocker while the renter can pay them. Each period is held in escrow
+// and paid to the owner once it has elapsed.
+func (k Keeper) Subscribe(ctx sdk.Context, msg msg.MsgSubscribe) (types.Subscription, error) {
+
+	renter := auth.GetSigner(ctx)
+
+	signer, nonce := txNonce(ctx)
+	subscriptionId := GenBookingID(msg.UUID, signer, nonce, ctx.BlockHeight(), auth.NextIDSeq(ctx))
+
+	if _, found := k.GetSubscription(ctx, subscriptionId); found {
+		return types.Subscription{}, fmt.Errorf(constants.BOOKING_ID_COLLISION,
+			subscriptionId)
+	}
+
+	asset, found := k.GetAsset(ctx, msg.UUID)
+	if !found {
+		return types.Subscription{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.UUID,
+			constants.STORE_ASSET)
+	}
+
+	if asset.Status == false {
+		return types.Subscription{}, fmt.Errorf(constants.BOOKING_ASSET_UNAVAILABLE,
+			asset.UUID)
+	}
+
+	// Periods are charged without the owner, who cannot answer each of them
+	if asset.RequiresApproval() {
+		return types.Subscription{}, fmt.Errorf(constants.SUBSCRIPTION_REQUIRES_APPROVAL,
+			asset.UUID)
+	}
+
+	if other, found := k.getActiveSubscription(ctx, asset.UUID); found {
+		return types.Subscription{}, fmt.Errorf(constants.BOOKING_SUBSCRIBED,
+			asset.UUID,
+			other.SubscriptionID)
+	}
+
+	// A subscription has no end, no booked slot may be left ahead of it
+	now := ctx.BlockHeader().Time.Unix()
+	if other, found := k.getOverlappingBooking(ctx, asset.UUID, now, math.MaxInt64); found {
+		return types.Subscription{}, fmt.Errorf(constants.BOOKING_SLOT_TAKEN,
+			asset.UUID,
+			other.Start,
+			other.End)
+	}
+
+	amount, err := subscriptionAmount(asset, msg.Period, now)
+	if err != nil {
+		return types.Subscription{}, err
+	}
+
+	if !amount.LTE(msg.MaxAmount) {
+		return types.Subscription{}, fmt.Errorf(constants.SUBSCRIPTION_EXCEEDS_MAX_AMOUNT,
+			amount.String(),
+			msg.MaxAmount.String())
+	}
+
+	subscription := types.NewSubscription(subscriptionId,
+		renter.GetAddress(),
+		asset.UUID,
+		msg.Period,
+		amount,
+		now,
+		constants.SUBSCRIPTION_STATUS_ACTIVE)
+
+	// Every period is charged to the grant the subscription was taken under
+	if grantee := auth.GetGrantee(ctx); grantee != nil {
+		subscription.Grantee = grantee.GetAddress()
+	}
+
+	if err := k.chargeSubscription(ctx, &subscription); err != nil {
+		return types.Subscription{}, fmt.Errorf(constants.BOOKING_INSUFFICIENT_BALANCE,
+			renter.GetAddress())
+	}
+
+	if err := k.setSubscription(ctx, subscription); err != nil {
+		return types.Subscription{}, err
+	}
+
+	k.setSubscriptionIndexes(ctx, subscription)
+
+	return subscription, nil
+}
+
+// CancelSubscription - renter or asset owner stops a subscription. A renter gives up the current period,
+// which is paid to the owner. An owner ending the rental refunds it.
+func (k Keeper) CancelSubscription(ctx sdk.Context, msg msg.MsgCancelSubscription) (types.Subscription, error) {
+
+	subscription, found := k.GetSubscription(ctx, msg.SubscriptionID)
+	if !found {
+		return types.Subscription{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.SubscriptionID,
+			constants.STORE_BOOKING)
+	}
+
+	if subscription.Status != constants.SUBSCRIPTION_STATUS_ACTIVE {
+		return types.Subscription{}, fmt.Errorf(constants.SUBSCRIPTION_NOT_ACTIVE,
+			subscription.SubscriptionID,
+			subscription.Status)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+	byRenter := bytes.Equal(signer, subscription.Renter)
+
+	asset, found := k.GetAsset(ctx, subscription.UUID)
+	if !byRenter && (!found || !bytes.Equal(signer, asset.Creator)) {
+		return types.Subscription{}, fmt.Errorf(constants.SUBSCRIPTION_CANCEL_UNAUTHORIZED,
+			subscription.SubscriptionID,
+			utils.ByteToString(signer))
+	}
+
+	to := subscription.Renter
+	if byRenter && found {
+		to = asset.Creator
+	}
+
+	if err := k.releaseHeldPeriod(ctx, &subscription, to); err != nil {
+		return types.Subscription{}, err
+	}
+
+	return k.endSubscription(ctx, subscription, constants.SUBSCRIPTION_STATUS_CANCELLED)
+}
+
+// ChargeSubscriptions - charge the subscriptions whose next period has started.
+// A renter who cannot pay loses the asset, the subscription lapses.
+func (k Keeper) ChargeSubscriptions(ctx sdk.Context) sdk.Tags {
+	store := ctx.KVStore(k.bookingKey)
+	now := ctx.BlockHeader().Time.Unix()
+
+	// collect first, the queue is modified while charging
+	var due []types.Subscription
+
+	iterator := store.Iterator(SubscriptionQueueKey, GetSubscriptionQueueTimeKey(now+1))
+	for ; iterator.Valid(); iterator.Next() {
+		subscription, found := k.GetSubscription(ctx, string(iterator.Value()))
+		if !found {
+			panic(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, string(iterator.Value()), constants.STORE_BOOKING))
+		}
+		due = append(due, subscription)
+	}
+	iterator.Close()
+
+	resTags := sdk.EmptyTags()
+
+	for _, subscription := range due {
+		asset, found := k.GetAsset(ctx, subscription.UUID)
+
+		// take the queue entry of the period just charged out
+		k.removeSubscriptionIndexes(ctx, subscription)
+
+		// the elapsed period is paid, or refunded when the asset is gone
+		to := subscription.Renter
+		if found {
+			to = asset.Creator
+		}
+		if err := k.releaseHeldPeriod(ctx, &subscription, to); err != nil {
+			panic(err)
+		}
+
+		if !found || k.chargeSubscription(ctx, &subscription) != nil {
+			subscription, err := k.endSubscription(ctx, subscription, constants.SUBSCRIPTION_STATUS_LAPSED)
+			if err != nil {
+				panic(err)
+			}
+
+			resTags = resTags.
+				AppendTag(tags.Event, tags.SubscriptionLapsed).
+				AppendTag(tags.SubscriptionId, subscription.SubscriptionID).
+				AppendTag(tags.UUID, subscription.UUID)
+			continue
+		}
+
+		if err := k.setSubscription(ctx, subscription); err != nil {
+			panic(err)
+		}
+		k.setSubscriptionIndexes(ctx, subscription)
+
+		resTags = resTags.
+			AppendTag(tags.Event, tags.SubscriptionCharged).
+			AppendTag(tags.SubscriptionId, subscription.SubscriptionID).
+			AppendTag(tags.Amount, subscription.Amount.String())
+	}
+
+	return resTags
+}
+
+// subscriptionAmount prices one period of a subscription starting at *start* with the asset pricing rules
+func subscriptionAmount(asset types.Asset, period string, start int64) (types.Coin, error) {
+	length, ok := constants.SUBSCRIPTION_PERIODS[period]
+	if !ok {
+		return types.Coin{}, fmt.Errorf(constants.SUBSCRIPTION_INVALID_PERIOD, period)
+	}
+
+	end := start + length
+
+	quote, err := quoteAsset(asset, start, end, asset.BillingUnits(start, end))
+	if err != nil {
+		return types.Coin{}, err
+	}
+	return quote.Payment, nil
+}
+
+// chargeSubscription holds the next period of a subscription in escrow and moves it to the following one
+func (k Keeper) chargeSubscription(ctx sdk.Context, subscription *types.Subscription) sdk.Error {
+	// checked first so a grant is never charged for a period the renter cannot pay
+	if !k.bankKeeper.GetCoins(ctx, subscription.Renter).GTE(subscription.Amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(constants.BOOKING_INSUFFICIENT_BALANCE,
+			utils.ByteToString(subscription.Renter)))
+	}
+
+	if len(subscription.Grantee) > 0 {
+		err := k.bankKeeper.ChargeGrant(ctx, subscription.Renter, subscription.Grantee, subscription.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := k.holdInEscrow(ctx, subscription.Renter, subscription.Amount); err != nil {
+		return err
+	}
+
+	subscription.Held = subscription.Amount
+	subscription.NextCharge += constants.SUBSCRIPTION_PERIODS[subscription.Period]
+	subscription.Charged++
+	return nil
+}
+
+// releaseHeldPeriod pays the period held for a subscription out of the escrow to *to*
+func (k Keeper) releaseHeldPeriod(ctx sdk.Context, subscription *types.Subscription, to sdk.AccAddress) sdk.Error {
+	if !subscription.HasHeldPeriod() {
+		return nil
+	}
+
+	if err := k.releaseFromEscrow(ctx, to, subscription.Held); err != nil {
+		return err
+	}
+
+	subscription.Held = types.NewCoin(subscription.Held.Denom, 0)
+	return nil
+}
+
+// endSubscription closes a subscription and frees its asset
+func (k Keeper) endSubscription(ctx sdk.Context, subscription types.Subscription, status string) (types.Subscription, error) {
+	k.removeSubscriptionIndexes(ctx, subscription)
+
+	subscription.Status = status
+
+	if err := k.setSubscription(ctx, subscription); err != nil {
+		return types.Subscription{}, err
+	}
+	return subscription, nil
+}
+
+// getActiveSubscription returns the subscription renting an asset, if any
+func (k Keeper) getActiveSubscription(ctx sdk.Context, uuid string) (types.Subscription, bool) {
+	store := ctx.KVStore(k.bookingKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetSubscriptionByAssetPrefix(uuid))
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return types.Subscription{}, false
+	}
+	return k.GetSubscription(ctx, string(iterator.Value()))
+}
+
+func (k Keeper) setSubscription(ctx sdk.Context, subscription types.Subscription) error {
+	store := ctx.KVStore(k.bookingKey)
+
+	err := utils.Store(store, GetSubscriptionKey(subscription.SubscriptionID), subscription)
+	if err != nil {
+		return fmt.Errorf(constants.ERROR_STORE_UPDATE,
+			"types.Subscription",
+			constants.STORE_BOOKING)
+	}
+	return nil
+}
+
+// setSubscriptionIndexes queues an active subscription for its next charge and marks its asset as rented
+func (k Keeper) setSubscriptionIndexes(ctx sdk.Context, subscription types.Subscription) {
+	store := ctx.KVStore(k.bookingKey)
+	id := []byte(subscription.SubscriptionID)
+
+	store.Set(GetSubscriptionQueueKey(subscription.NextCharge, subscription.SubscriptionID), id)
+	store.Set(GetSubscriptionByAssetKey(subscription.UUID, subscription.SubscriptionID), id)
+}
+
+// removeSubscriptionIndexes takes a subscription out of the charge queue and frees its asset
+func (k Keeper) removeSubscriptionIndexes(ctx sdk.Context, subscription types.Subscription) {
+	store := ctx.KVStore(k.bookingKey)
+
+	store.Delete(GetSubscriptionQueueKey(subscription.NextCharge, subscription.SubscriptionID))
+	store.Delete(GetSubscriptionByAssetKey(subscription.UUID, subscription.SubscriptionID))
+}
@@ -7,9 +7,33 @@ var (
 	Event     = "Event"
 	BookingId = "BookingId"
 	UUID      = "UUID"
+	Start     = "Start"
+	End       = "End"
+	Refund    = "Refund"
+	Payout    = "Payout"
+	Signer    = "Signer"
+	Evidence  = "Evidence"
+	Height    = "Height"
+	Override  = "Override"
+
+	SubscriptionId = "SubscriptionId"
+	Period         = "Period"
 
 	//Value -  []byte
 
-	BookingCompleted = "BookingCompleted"
-	BookingStarted   = "BookingStarted"
+	BookingCompleted  = "BookingCompleted"
+	BookingStarted    = "BookingStarted"
+	BookingCancelled  = "BookingCancelled"
+	BookingConfirmed  = "BookingConfirmed"
+	BookingRejected   = "BookingRejected"
+	BookingExpired    = "BookingExpired"
+	BookingCheckedIn  = "BookingCheckedIn"
+	BookingCheckedOut = "BookingCheckedOut"
+	DepositClaimed    = "DepositClaimed"
+	DepositReturned   = "DepositReturned"
+
+	SubscriptionStarted   = "SubscriptionStarted"
+	SubscriptionCharged   = "SubscriptionCharged"
+	SubscriptionLapsed    = "SubscriptionLapsed"
+	SubscriptionCancelled = "SubscriptionCancelled"
 )
@@ -0,0 +1,96 @@
+package dispute
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/dispute/tags"
+)
+
+// EndBlocker - resolves disputes whose voting period is over with the votes cast so far
+// and periodically checks that frozen funds are all accounted for.
+func EndBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	store := ctx.KVStore(k.storeKey)
+	now := ctx.BlockHeader().Time.Unix()
+
+	// collect first, the queue is modified while resolving
+	var expired []types.Dispute
+
+	iterator := store.Iterator(DisputeQueueKey, GetDisputeQueueTimeKey(now+1))
+	for ; iterator.Valid(); iterator.Next() {
+		dispute, found := k.GetDispute(ctx, string(iterator.Value()))
+		if !found {
+			panic(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, string(iterator.Value()), constants.STORE_DISPUTE))
+		}
+		expired = append(expired, dispute)
+	}
+	iterator.Close()
+
+	resTags := sdk.EmptyTags()
+
+	for _, dispute := range expired {
+		resolved, err := k.resolve(ctx, dispute)
+		if err != nil {
+			panic(err)
+		}
+
+		resTags = resTags.AppendTags(resolvedTags(resolved))
+	}
+
+	checkFrozen(ctx, k)
+
+	return resTags
+}
+
+// checkFrozen - checks the frozen funds every DISPUTE_INVARIANT_PERIOD blocks.
+// Summing open disputes is costly, a mismatch halts the chain only if DISPUTE_INVARIANT_HALT is set.
+func checkFrozen(ctx sdk.Context, k Keeper) {
+	if constants.DISPUTE_INVARIANT_PERIOD <= 0 || ctx.BlockHeight()%constants.DISPUTE_INVARIANT_PERIOD != 0 {
+		return
+	}
+
+	err := FrozenInvariant(ctx, k)
+	if err == nil {
+		return
+	}
+
+	if constants.DISPUTE_INVARIANT_HALT {
+		panic(err)
+	}
+
+	constants.LOGGER.Error("Frozen invariant broken",
+		"height", ctx.BlockHeight(),
+		"err", err.Error(),
+	)
+}
+
+// FrozenInvariant checks that the dispute account holds at least the funds of open disputes.
+// Anyone can send coins to the dispute account, more than is frozen is not a mismatch.
+func FrozenInvariant(ctx sdk.Context, k Keeper) error {
+	held := k.bankKeeper.GetCoins(ctx, FrozenAddress)
+	frozen := k.GetFrozen(ctx)
+
+	for _, denom := range constants.ALL_DENOMS {
+		if held.GetCoin(denom).LT(frozen.GetCoin(denom)) {
+			return fmt.Errorf(constants.DISPUTE_FROZEN_MISMATCH,
+				held.String(),
+				frozen.String())
+		}
+	}
+	return nil
+}
+
+// resolvedTags - tags emitted when a dispute is resolved
+func resolvedTags(dispute types.Dispute) sdk.Tags {
+	renterPart, ownerPart := dispute.Split(dispute.RenterShare)
+
+	return sdk.NewTags(tags.Event, tags.DisputeResolved).
+		AppendTag(tags.BookingId, dispute.BookingID).
+		AppendTag(tags.RenterShare, strconv.FormatInt(dispute.RenterShare, 10)).
+		AppendTag(tags.RenterPart, renterPart.String()).
+		AppendTag(tags.OwnerPart, ownerPart.String())
+}
@@ -0,0 +1,68 @@
+package dispute
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	msg "github.com/sharering/shareledger/x/dispute/messages"
+)
+
+// IsArbitrator - whether an account is appointed as arbitrator
+func (k Keeper) IsArbitrator(ctx sdk.Context, addr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetArbitratorKey(addr))
+}
+
+// GetArbitrators returns all appointed arbitrators
+func (k Keeper) GetArbitrators(ctx sdk.Context) (arbitrators []sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ArbitratorKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		arbitrators = append(arbitrators, sdk.AccAddress(iterator.Value()))
+	}
+	return arbitrators
+}
+
+// AddArbitrator - appoint an arbitrator. Only privileged accounts manage the list.
+// The chain has no governance module, the reserve accounts govern it: they also
+// register issuers in bank and set the fee schedule.
+func (k Keeper) AddArbitrator(ctx sdk.Context, msg msg.MsgAddArbitrator) error {
+
+	if !utils.IsValidReserve(auth.GetSigner(ctx).GetAddress()) {
+		return fmt.Errorf(constants.RES_RESERVE_ONLY)
+	}
+
+	if k.IsArbitrator(ctx, msg.Arbitrator) {
+		return fmt.Errorf(constants.DISPUTE_ARBITRATOR_EXISTS,
+			msg.Arbitrator.String())
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetArbitratorKey(msg.Arbitrator), msg.Arbitrator.Bytes())
+
+	return nil
+}
+
+// RemoveArbitrator - dismiss an arbitrator. Votes already cast are kept
+func (k Keeper) RemoveArbitrator(ctx sdk.Context, msg msg.MsgRemoveArbitrator) error {
+
+	if !utils.IsValidReserve(auth.GetSigner(ctx).GetAddress()) {
+		return fmt.Errorf(constants.RES_RESERVE_ONLY)
+	}
+
+	if !k.IsArbitrator(ctx, msg.Arbitrator) {
+		return fmt.Errorf(constants.DISPUTE_NOT_ARBITRATOR,
+			msg.Arbitrator.String())
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetArbitratorKey(msg.Arbitrator))
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package dispute
+
+import (
+	"github.com/tendermint/go-amino"
+	msg "github.com/sharering/shareledger/x/dispute/messages"
+)
+
+func RegisterCodec(cdc *amino.Codec) *amino.Codec {
+	cdc.RegisterConcrete(msg.MsgOpenDispute{}, "shareledger/dispute/MsgOpenDispute", nil)
+	cdc.RegisterConcrete(msg.MsgVoteDispute{}, "shareledger/dispute/MsgVoteDispute", nil)
+	cdc.RegisterConcrete(msg.MsgAddArbitrator{}, "shareledger/dispute/MsgAddArbitrator", nil)
+	cdc.RegisterConcrete(msg.MsgRemoveArbitrator{}, "shareledger/dispute/MsgRemoveArbitrator", nil)
+	return cdc
+}
@@ -0,0 +1,110 @@
+package dispute
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/dispute/messages"
+	"github.com/sharering/shareledger/x/dispute/tags"
+
+	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
+)
+
+func NewHandler(k Keeper) sdkTypes.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdkTypes.Result {
+
+		constants.LOGGER.Info(
+			"Msg for Dispute Module",
+			"type", reflect.TypeOf(msg),
+			"msg", msg,
+		)
+
+		var ret sdk.Result
+
+		switch msg := msg.(type) {
+		case messages.MsgOpenDispute:
+			ret = handleOpen(ctx, k, msg)
+		case messages.MsgVoteDispute:
+			ret = handleVote(ctx, k, msg)
+		case messages.MsgAddArbitrator:
+			ret = handleAddArbitrator(ctx, k, msg)
+		case messages.MsgRemoveArbitrator:
+			ret = handleRemoveArbitrator(ctx, k, msg)
+
+		default:
+			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
+			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
+		}
+
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(ret)
+	}
+}
+
+func handleOpen(ctx sdk.Context, k Keeper, msg messages.MsgOpenDispute) sdk.Result {
+
+	dispute, err := k.Open(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("%s", dispute.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.Claimant, auth.GetSigner(ctx).GetAddress().String()),
+	}
+}
+
+func handleVote(ctx sdk.Context, k Keeper, msg messages.MsgVoteDispute) sdk.Result {
+
+	dispute, err := k.Vote(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	resTags := msg.Tags().
+		AppendTag(tags.Arbitrator, auth.GetSigner(ctx).GetAddress().String())
+
+	if dispute.IsResolved {
+		resTags = resTags.AppendTags(resolvedTags(dispute))
+	}
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("%s", dispute.String()),
+		Tags: resTags,
+	}
+}
+
+func handleAddArbitrator(ctx sdk.Context, k Keeper, msg messages.MsgAddArbitrator) sdk.Result {
+
+	err := k.AddArbitrator(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("Added arbitrator %s", msg.Arbitrator.String()),
+		Tags: msg.Tags(),
+	}
+}
+
+func handleRemoveArbitrator(ctx sdk.Context, k Keeper, msg messages.MsgRemoveArbitrator) sdk.Result {
+
+	err := k.RemoveArbitrator(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  fmt.Sprintf("Removed arbitrator %s", msg.Arbitrator.String()),
+		Tags: msg.Tags(),
+	}
+}
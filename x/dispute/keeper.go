@@ -0,0 +1,213 @@
+package dispute
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking"
+	msg "github.com/sharering/shareledger/x/dispute/messages"
+)
+
+// FrozenAddress - account holding the funds of bookings under dispute.
+// No private key exists for this address, only the dispute module moves its coins.
+var FrozenAddress = sdk.AccAddress(crypto.AddressHash([]byte("dispute/frozen")))
+
+type Keeper struct {
+	storeKey      sdk.StoreKey   // key used to access the store from the context
+	bookingKeeper booking.Keeper // freezes funds held for bookings
+	bankKeeper    bank.Keeper    // pays out frozen funds
+	cdc           *amino.Codec
+}
+
+func NewKeeper(key sdk.StoreKey, bk booking.Keeper, bank bank.Keeper, cdc *amino.Codec) Keeper {
+	return Keeper{
+		storeKey:      key,
+		bookingKeeper: bk,
+		bankKeeper:    bank,
+		cdc:           cdc,
+	}
+}
+
+//-----------------------------------------------
+
+// GetDispute returns the dispute of a booking
+func (k Keeper) GetDispute(ctx sdk.Context, bookingID string) (dispute types.Dispute, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetDisputeKey(bookingID))
+	if bz == nil {
+		return dispute, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &dispute)
+	return dispute, true
+}
+
+func (k Keeper) setDispute(ctx sdk.Context, dispute types.Dispute) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDisputeKey(dispute.BookingID), k.cdc.MustMarshalBinaryLengthPrefixed(dispute))
+}
+
+// Open - renter or asset owner disputes a booking. Everything held in escrow
+// for the booking is frozen until the dispute is resolved.
+func (k Keeper) Open(ctx sdk.Context, msg msg.MsgOpenDispute) (types.Dispute, error) {
+
+	if _, found := k.GetDispute(ctx, msg.BookingID); found {
+		return types.Dispute{}, fmt.Errorf(constants.DISPUTE_ALREADY_EXISTS,
+			msg.BookingID)
+	}
+
+	bk, found := k.bookingKeeper.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Dispute{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
+	}
+
+	asset, found := k.bookingKeeper.GetAsset(ctx, bk.UUID)
+	if !found {
+		return types.Dispute{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			bk.UUID,
+			constants.STORE_ASSET)
+	}
+
+	signer := auth.GetSigner(ctx).GetAddress()
+
+	if !bytes.Equal(signer, bk.Renter) && !bytes.Equal(signer, asset.Creator) {
+		return types.Dispute{}, fmt.Errorf(constants.DISPUTE_NOT_PARTY,
+			bk.BookingID,
+			utils.ByteToString(signer))
+	}
+
+	_, frozen, err := k.bookingKeeper.Freeze(ctx, bk.BookingID, FrozenAddress)
+	if err != nil {
+		return types.Dispute{}, err
+	}
+
+	dispute := types.NewDispute(bk.BookingID,
+		signer,
+		bk.Renter,
+		asset.Creator,
+		msg.Evidence,
+		frozen,
+		ctx.BlockHeader().Time.Unix()+constants.DISPUTE_VOTING_PERIOD)
+
+	k.setDispute(ctx, dispute)
+	k.setDisputeQueue(ctx, dispute)
+
+	return dispute, nil
+}
+
+// Vote - arbitrator votes on the split of frozen funds. The dispute is resolved
+// as soon as a majority of arbitrators has voted.
+func (k Keeper) Vote(ctx sdk.Context, msg msg.MsgVoteDispute) (types.Dispute, error) {
+
+	dispute, found := k.GetDispute(ctx, msg.BookingID)
+	if !found {
+		return types.Dispute{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_DISPUTE)
+	}
+
+	if dispute.IsResolved {
+		return types.Dispute{}, fmt.Errorf(constants.DISPUTE_RESOLVED,
+			dispute.BookingID)
+	}
+
+	arbitrator := auth.GetSigner(ctx).GetAddress()
+
+	if !k.IsArbitrator(ctx, arbitrator) {
+		return types.Dispute{}, fmt.Errorf(constants.DISPUTE_NOT_ARBITRATOR,
+			arbitrator.String())
+	}
+
+	if dispute.HasVoted(arbitrator) {
+		return types.Dispute{}, fmt.Errorf(constants.DISPUTE_ALREADY_VOTED,
+			arbitrator.String(),
+			dispute.BookingID)
+	}
+
+	dispute.Votes = append(dispute.Votes, types.DisputeVote{
+		Arbitrator:  arbitrator,
+		RenterShare: msg.RenterShare,
+	})
+
+	if len(dispute.Votes) > len(k.GetArbitrators(ctx))/2 {
+		return k.resolve(ctx, dispute)
+	}
+
+	k.setDispute(ctx, dispute)
+	return dispute, nil
+}
+
+// resolve - pay out the frozen funds following the votes cast so far
+func (k Keeper) resolve(ctx sdk.Context, dispute types.Dispute) (types.Dispute, error) {
+
+	dispute.RenterShare = dispute.AverageShare(constants.DISPUTE_DEFAULT_RENTER_SHARE)
+	dispute.IsResolved = true
+
+	renterPart, ownerPart := dispute.Split(dispute.RenterShare)
+
+	if err := k.payOut(ctx, dispute.Renter, renterPart); err != nil {
+		return types.Dispute{}, err
+	}
+
+	if err := k.payOut(ctx, dispute.Owner, ownerPart); err != nil {
+		return types.Dispute{}, err
+	}
+
+	k.removeDisputeQueue(ctx, dispute)
+	k.setDispute(ctx, dispute)
+
+	return dispute, nil
+}
+
+// payOut moves frozen funds to an account
+func (k Keeper) payOut(ctx sdk.Context, to sdk.AccAddress, amt types.Coin) sdk.Error {
+	_, err := k.bankKeeper.SubtractCoin(ctx, FrozenAddress, amt)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.bankKeeper.AddCoin(ctx, to, amt)
+	return err
+}
+
+// GetFrozen returns the sum of funds frozen by disputes which are not yet resolved
+func (k Keeper) GetFrozen(ctx sdk.Context) types.Coins {
+	frozen := types.NewDefaultCoins()
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DisputeQueueKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		dispute, found := k.GetDispute(ctx, string(iterator.Value()))
+		if !found {
+			panic(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND, string(iterator.Value()), constants.STORE_DISPUTE))
+		}
+		frozen = frozen.Plus(dispute.Frozen)
+	}
+	return frozen
+}
+
+// setDisputeQueue puts a dispute in the queue of disputes timing out
+func (k Keeper) setDisputeQueue(ctx sdk.Context, dispute types.Dispute) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDisputeQueueKey(dispute.Deadline, dispute.BookingID), []byte(dispute.BookingID))
+}
+
+// removeDisputeQueue takes a dispute out of the queue of disputes timing out
+func (k Keeper) removeDisputeQueue(ctx sdk.Context, dispute types.Dispute) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDisputeQueueKey(dispute.Deadline, dispute.BookingID))
+}
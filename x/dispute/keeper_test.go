@@ -0,0 +1,291 @@
+package dispute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking"
+	bookingMsg "github.com/sharering/shareledger/x/booking/messages"
+	msg "github.com/sharering/shareledger/x/dispute/messages"
+	"github.com/sharering/shareledger/x/exchange"
+)
+
+const (
+	testNow  = int64(1000000)
+	testHour = int64(60 * 60)
+)
+
+var (
+	testOwner      = sdk.AccAddress([]byte("owner_______________"))
+	testRenter     = sdk.AccAddress([]byte("renter______________"))
+	testStranger   = sdk.AccAddress([]byte("stranger____________"))
+	testReserve, _ = sdk.AccAddressFromHex(constants.DEFAULT_RESERVE)
+)
+
+type testInput struct {
+	ctx sdk.Context
+	k   Keeper
+	bk  bank.Keeper
+	am  auth.AccountMapper
+}
+
+func setupTestInput(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+	bookingKey := sdk.NewKVStoreKey(constants.STORE_BOOKING)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+	disputeKey := sdk.NewKVStoreKey(constants.STORE_DISPUTE)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, assetKey, bookingKey, bankKey, exchangeKey, disputeKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	bk := bank.NewKeeper(am, bankKey, cdc)
+	ek := exchange.NewKeeper(exchangeKey, bk)
+	bookingKeeper := booking.NewKeeper(bookingKey, assetKey, bk, ek, cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(testNow, 0)}, false, log.NewNopLogger())
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	if err := utils.Store(ctx.KVStore(assetKey), []byte(asset.UUID), asset); err != nil {
+		t.Fatalf("Storing asset failed. %s", err)
+	}
+
+	return testInput{
+		ctx: ctx,
+		k:   NewKeeper(disputeKey, bookingKeeper, bk, cdc),
+		bk:  bk,
+		am:  am,
+	}
+}
+
+// signedBy returns the context of a transaction signed by *addr*
+func (in testInput) signedBy(addr sdk.AccAddress) sdk.Context {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		acc = auth.NewSHRAccountWithAddress(addr)
+	}
+	acc.SetNonce(acc.GetNonce() + 1)
+	in.am.SetAccount(in.ctx, acc)
+
+	return auth.WithSigners(in.ctx, acc)
+}
+
+// book funds the renter with 100 and books the asset for 20, returning the booking ID
+func (in testInput) book(t *testing.T) string {
+	amt := types.NewCoin(constants.BOOKING_DENOM, 100)
+	if _, err := in.bk.AddCoin(in.ctx, testRenter, amt); err != nil {
+		t.Fatalf("Funding failed. %s", err)
+	}
+	in.bk.AddSupply(in.ctx, amt)
+
+	bk, err := in.k.bookingKeeper.Book(in.signedBy(testRenter), bookingMsg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	return bk.BookingID
+}
+
+func (in testInput) appoint(t *testing.T, arbitrators ...sdk.AccAddress) {
+	for _, arbitrator := range arbitrators {
+		if err := in.k.AddArbitrator(in.signedBy(testReserve), msg.NewMsgAddArbitrator(arbitrator)); err != nil {
+			t.Fatalf("Appointing arbitrator failed. %s", err)
+		}
+	}
+}
+
+func (in testInput) balance(addr sdk.AccAddress) types.Coin {
+	return in.bk.GetCoins(in.ctx, addr).GetCoin(constants.BOOKING_DENOM)
+}
+
+func (in testInput) checkInvariants(t *testing.T) {
+	if err := FrozenInvariant(in.ctx, in.k); err != nil {
+		t.Errorf("Frozen invariant broken. %s", err)
+	}
+	if err := in.bk.CheckSupply(in.ctx); err != nil {
+		t.Errorf("Supply invariant broken. %s", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	in := setupTestInput(t)
+	bookingID := in.book(t)
+
+	if _, err := in.k.Open(in.signedBy(testStranger), msg.NewMsgOpenDispute(bookingID, nil)); err == nil {
+		t.Errorf("Opening a dispute on someone else's booking should fail.")
+	}
+
+	dispute, err := in.k.Open(in.signedBy(testRenter), msg.NewMsgOpenDispute(bookingID, []byte("evidence")))
+	if err != nil {
+		t.Fatalf("Opening a dispute failed. %s", err)
+	}
+
+	// the payment held for the booking is frozen until voting ends
+	if !dispute.Frozen.Equal(types.NewCoin(constants.BOOKING_DENOM, 20)) ||
+		!in.balance(FrozenAddress).Equal(dispute.Frozen) {
+		t.Errorf("Dispute should freeze 20, holds %s.", in.balance(FrozenAddress).String())
+	}
+
+	if dispute.Deadline != testNow+constants.DISPUTE_VOTING_PERIOD {
+		t.Errorf("Voting should close after the voting period, closes at %d.", dispute.Deadline)
+	}
+
+	if _, err := in.k.Open(in.signedBy(testOwner), msg.NewMsgOpenDispute(bookingID, nil)); err == nil {
+		t.Errorf("Opening a second dispute on a booking should fail.")
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestVoteMajority(t *testing.T) {
+	in := setupTestInput(t)
+
+	arbitrators := []sdk.AccAddress{
+		sdk.AccAddress([]byte("arbitrator1_________")),
+		sdk.AccAddress([]byte("arbitrator2_________")),
+		sdk.AccAddress([]byte("arbitrator3_________")),
+	}
+	in.appoint(t, arbitrators...)
+
+	if err := in.k.AddArbitrator(in.signedBy(testStranger), msg.NewMsgAddArbitrator(testStranger)); err == nil {
+		t.Errorf("Appointing an arbitrator without a reserve account should fail.")
+	}
+
+	bookingID := in.book(t)
+	if _, err := in.k.Open(in.signedBy(testOwner), msg.NewMsgOpenDispute(bookingID, nil)); err != nil {
+		t.Fatalf("Opening a dispute failed. %s", err)
+	}
+
+	if _, err := in.k.Vote(in.signedBy(testStranger), msg.NewMsgVoteDispute(bookingID, 100)); err == nil {
+		t.Errorf("Voting without being an arbitrator should fail.")
+	}
+
+	dispute, err := in.k.Vote(in.signedBy(arbitrators[0]), msg.NewMsgVoteDispute(bookingID, 60))
+	if err != nil {
+		t.Fatalf("Voting failed. %s", err)
+	}
+	if dispute.IsResolved {
+		t.Errorf("Dispute should stay open before a majority has voted.")
+	}
+
+	if _, err := in.k.Vote(in.signedBy(arbitrators[0]), msg.NewMsgVoteDispute(bookingID, 60)); err == nil {
+		t.Errorf("Voting twice should fail.")
+	}
+
+	dispute, err = in.k.Vote(in.signedBy(arbitrators[1]), msg.NewMsgVoteDispute(bookingID, 80))
+	if err != nil {
+		t.Fatalf("Voting failed. %s", err)
+	}
+
+	// two out of three is a majority, frozen funds are split on the average share
+	if !dispute.IsResolved || dispute.RenterShare != 70 {
+		t.Fatalf("Dispute should be resolved with a 70%% renter share, got %v with %d%%.",
+			dispute.IsResolved, dispute.RenterShare)
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 94)) ||
+		!in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 6)) ||
+		!in.balance(FrozenAddress).IsZero() {
+		t.Errorf("Renter should get 14 and owner 6, got %s and %s.",
+			in.balance(testRenter).String(), in.balance(testOwner).String())
+	}
+
+	if _, err := in.k.Vote(in.signedBy(arbitrators[2]), msg.NewMsgVoteDispute(bookingID, 0)); err == nil {
+		t.Errorf("Voting on a resolved dispute should fail.")
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestVotingPeriodTimeout(t *testing.T) {
+	in := setupTestInput(t)
+	in.appoint(t, sdk.AccAddress([]byte("arbitrator1_________")))
+
+	bookingID := in.book(t)
+	dispute, err := in.k.Open(in.signedBy(testRenter), msg.NewMsgOpenDispute(bookingID, nil))
+	if err != nil {
+		t.Fatalf("Opening a dispute failed. %s", err)
+	}
+
+	header := in.ctx.BlockHeader()
+	header.Time = time.Unix(dispute.Deadline-1, 0)
+	in.ctx = in.ctx.WithBlockHeader(header)
+	EndBlocker(in.ctx, in.k)
+
+	if dispute, _ := in.k.GetDispute(in.ctx, bookingID); dispute.IsResolved {
+		t.Errorf("Dispute should stay open before its deadline.")
+	}
+
+	header.Time = time.Unix(dispute.Deadline, 0)
+	in.ctx = in.ctx.WithBlockHeader(header)
+	EndBlocker(in.ctx, in.k)
+
+	// nobody voted, the default share applies
+	dispute, _ = in.k.GetDispute(in.ctx, bookingID)
+	if !dispute.IsResolved || dispute.RenterShare != constants.DISPUTE_DEFAULT_RENTER_SHARE {
+		t.Fatalf("Dispute should be resolved with the default share at its deadline.")
+	}
+
+	if !in.balance(testRenter).Equal(types.NewCoin(constants.BOOKING_DENOM, 90)) ||
+		!in.balance(testOwner).Equal(types.NewCoin(constants.BOOKING_DENOM, 10)) {
+		t.Errorf("Renter and owner should get 10 each, got %s and %s.",
+			in.balance(testRenter).String(), in.balance(testOwner).String())
+	}
+
+	in.checkInvariants(t)
+}
+
+func TestFrozenInvariant(t *testing.T) {
+	in := setupTestInput(t)
+
+	bookingID := in.book(t)
+	if _, err := in.k.Open(in.signedBy(testRenter), msg.NewMsgOpenDispute(bookingID, nil)); err != nil {
+		t.Fatalf("Opening a dispute failed. %s", err)
+	}
+
+	// anyone can send coins to the dispute account
+	one := types.NewCoin(constants.BOOKING_DENOM, 1)
+	if _, err := in.bk.AddCoin(in.ctx, FrozenAddress, one); err != nil {
+		t.Fatalf("Sending to the dispute account failed. %s", err)
+	}
+	in.bk.AddSupply(in.ctx, one)
+
+	if err := FrozenInvariant(in.ctx, in.k); err != nil {
+		t.Errorf("Coins sent to the dispute account should not break the invariant. %s", err)
+	}
+
+	if _, err := in.bk.SubtractCoin(in.ctx, FrozenAddress, types.NewCoin(constants.BOOKING_DENOM, 2)); err != nil {
+		t.Fatalf("Taking from the dispute account failed. %s", err)
+	}
+
+	if err := FrozenInvariant(in.ctx, in.k); err == nil {
+		t.Errorf("Dispute account holding less than is frozen should break the invariant.")
+	}
+}
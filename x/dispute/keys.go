@@ -0,0 +1,40 @@
+package dispute
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	DisputeKey      = []byte{0x01} // prefix for each key to a dispute, by booking
+	ArbitratorKey   = []byte{0x02} // prefix for each key to an appointed arbitrator
+	DisputeQueueKey = []byte{0x03} // prefix for each key to an open dispute, by voting deadline
+)
+
+// gets the key for the dispute of a booking
+// VALUE: types.Dispute
+func GetDisputeKey(bookingID string) []byte {
+	return append(DisputeKey, []byte(bookingID)...)
+}
+
+// gets the key for an arbitrator
+// VALUE: sdk.AccAddress
+func GetArbitratorKey(addr sdk.AccAddress) []byte {
+	return append(ArbitratorKey, addr.Bytes()...)
+}
+
+// gets the prefix for all disputes whose voting closes at deadline
+func GetDisputeQueueTimeKey(deadline int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(deadline))
+	return append(DisputeQueueKey, bz...)
+}
+
+// gets the key for an open dispute
+// VALUE: BookingID
+func GetDisputeQueueKey(deadline int64, bookingID string) []byte {
+	return append(GetDisputeQueueTimeKey(deadline), []byte(bookingID)...)
+}
@@ -0,0 +1,118 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/dispute/tags"
+)
+
+// MsgAddArbitrator - privileged accounts appoint an arbitrator
+type MsgAddArbitrator struct {
+	Arbitrator sdk.AccAddress `json:"arbitrator"`
+}
+
+var _ sdk.Msg = MsgAddArbitrator{}
+
+func NewMsgAddArbitrator(arbitrator sdk.AccAddress) MsgAddArbitrator {
+	return MsgAddArbitrator{
+		Arbitrator: arbitrator,
+	}
+}
+
+func (msg MsgAddArbitrator) Route() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgAddArbitrator) Type() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgAddArbitrator) ValidateBasic() sdk.Error {
+	if len(msg.Arbitrator) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address")
+	}
+
+	return nil
+}
+
+func (msg MsgAddArbitrator) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgAddArbitrator) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgAddArbitrator) String() string {
+	return fmt.Sprintf("Dispute/MsgAddArbitrator{Arbitrator: %s}", msg.Arbitrator.String())
+}
+
+func (msg MsgAddArbitrator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgAddArbitrator) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.ArbitratorAdded).
+		AppendTag(tags.Arbitrator, msg.Arbitrator.String())
+}
+
+//---------------------------------------------------------------
+
+// MsgRemoveArbitrator - privileged accounts dismiss an arbitrator
+type MsgRemoveArbitrator struct {
+	Arbitrator sdk.AccAddress `json:"arbitrator"`
+}
+
+var _ sdk.Msg = MsgRemoveArbitrator{}
+
+func NewMsgRemoveArbitrator(arbitrator sdk.AccAddress) MsgRemoveArbitrator {
+	return MsgRemoveArbitrator{
+		Arbitrator: arbitrator,
+	}
+}
+
+func (msg MsgRemoveArbitrator) Route() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgRemoveArbitrator) Type() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgRemoveArbitrator) ValidateBasic() sdk.Error {
+	if len(msg.Arbitrator) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address")
+	}
+
+	return nil
+}
+
+func (msg MsgRemoveArbitrator) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgRemoveArbitrator) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgRemoveArbitrator) String() string {
+	return fmt.Sprintf("Dispute/MsgRemoveArbitrator{Arbitrator: %s}", msg.Arbitrator.String())
+}
+
+func (msg MsgRemoveArbitrator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgRemoveArbitrator) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.ArbitratorRemoved).
+		AppendTag(tags.Arbitrator, msg.Arbitrator.String())
+}
@@ -0,0 +1,70 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/dispute/tags"
+)
+
+// MsgOpenDispute - renter or asset owner disputes a booking
+type MsgOpenDispute struct {
+	BookingID string `json:"bookingId"`
+	Evidence  []byte `json:"evidence"` // hash of the off-chain statement of the claimant
+}
+
+var _ sdk.Msg = MsgOpenDispute{}
+
+func NewMsgOpenDispute(bookingId string, evidence []byte) MsgOpenDispute {
+	return MsgOpenDispute{
+		BookingID: bookingId,
+		Evidence:  evidence,
+	}
+}
+
+func (msg MsgOpenDispute) Route() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgOpenDispute) Type() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgOpenDispute) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if len(msg.Evidence) == 0 {
+		return sdk.ErrUnknownRequest("Evidence hash is required")
+	}
+
+	return nil
+}
+
+func (msg MsgOpenDispute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgOpenDispute) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgOpenDispute) String() string {
+	return fmt.Sprintf("Dispute/MsgOpenDispute{BookingID: %s, Evidence: %X}", msg.BookingID, msg.Evidence)
+}
+
+func (msg MsgOpenDispute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgOpenDispute) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DisputeOpened).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.Evidence, fmt.Sprintf("%X", msg.Evidence))
+}
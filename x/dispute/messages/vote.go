@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/dispute/tags"
+)
+
+// MsgVoteDispute - arbitrator proposes the percentage of frozen funds awarded to the renter
+type MsgVoteDispute struct {
+	BookingID   string `json:"bookingId"`
+	RenterShare int64  `json:"renter_share"`
+}
+
+var _ sdk.Msg = MsgVoteDispute{}
+
+func NewMsgVoteDispute(bookingId string, renterShare int64) MsgVoteDispute {
+	return MsgVoteDispute{
+		BookingID:   bookingId,
+		RenterShare: renterShare,
+	}
+}
+
+func (msg MsgVoteDispute) Route() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgVoteDispute) Type() string {
+	return constants.MESSAGE_DISPUTE
+}
+
+func (msg MsgVoteDispute) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if msg.RenterShare < 0 || msg.RenterShare > 100 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.DISPUTE_INVALID_SHARE, msg.RenterShare))
+	}
+
+	return nil
+}
+
+func (msg MsgVoteDispute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgVoteDispute) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgVoteDispute) String() string {
+	return fmt.Sprintf("Dispute/MsgVoteDispute{BookingID: %s, RenterShare: %d}", msg.BookingID, msg.RenterShare)
+}
+
+func (msg MsgVoteDispute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgVoteDispute) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.DisputeVoted).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.RenterShare, strconv.FormatInt(msg.RenterShare, 10))
+}
@@ -0,0 +1,68 @@
+package dispute
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// query endpoints supported by dispute querier
+const (
+	QueryDispute     = "dispute"
+	QueryArbitrators = "arbitrators"
+)
+
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryDispute:
+			return queryDispute(ctx, cdc, req, k)
+		case QueryArbitrators:
+			return queryArbitrators(ctx, cdc, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown dispute query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/dispute/dispute'
+type QueryDisputeParams struct {
+	BookingID string
+}
+
+func queryDispute(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDisputeParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryDisputeParams"))
+	}
+
+	dispute, found := k.GetDispute(ctx, params.BookingID)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_STORE_NOT_FOUND,
+			params.BookingID,
+			constants.STORE_DISPUTE))
+	}
+
+	res, errRes = cdc.MarshalJSON(dispute)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "types.Dispute"))
+	}
+	return res, nil
+}
+
+func queryArbitrators(ctx sdk.Context, cdc *amino.Codec, k Keeper) (res []byte, err sdk.Error) {
+	arbitrators := k.GetArbitrators(ctx)
+
+	res, errRes := cdc.MarshalJSON(arbitrators)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "Arbitrators"))
+	}
+	return res, nil
+}
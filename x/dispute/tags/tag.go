@@ -0,0 +1,22 @@
+package tags
+
+var (
+	//Key - String type
+
+	Event       = "Event"
+	BookingId   = "BookingId"
+	Claimant    = "Claimant"
+	Arbitrator  = "Arbitrator"
+	RenterShare = "RenterShare"
+	RenterPart  = "RenterPart"
+	OwnerPart   = "OwnerPart"
+	Evidence    = "Evidence"
+
+	//Value -  []byte
+
+	DisputeOpened     = "DisputeOpened"
+	DisputeVoted      = "DisputeVoted"
+	DisputeResolved   = "DisputeResolved"
+	ArbitratorAdded   = "ArbitratorAdded"
+	ArbitratorRemoved = "ArbitratorRemoved"
+)
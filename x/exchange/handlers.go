@@ -6,7 +6,6 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
-	"github.com/sharering/shareledger/utils"
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/exchange/messages"
 
@@ -32,16 +31,8 @@ func NewHandler(k Keeper) sdkTypes.Handler {
 			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
 		}
 
-		if !ret.IsOK() {
-			return sdkTypes.NewResult(ret)
-		}
-
-		fee, denom := utils.GetMsgFee(msg)
-		return sdkTypes.Result{
-			Result:    ret,
-			FeeDenom:  denom,
-			FeeAmount: fee,
-		}
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(ret)
 
 	}
 }
@@ -54,10 +54,7 @@ func GetAllReserve() []Reserve {
 			panic(err)
 		}
 
-		var addr sdk.AccAddress
-		copy(addr[:], decoded)
-
-		allRes = append(allRes, NewReserve(addr))
+		allRes = append(allRes, NewReserve(sdk.AccAddress(decoded)))
 	}
 	return allRes
 }
@@ -0,0 +1,8 @@
+package fee
+
+import "github.com/tendermint/go-amino"
+
+func RegisterCodec(cdc *amino.Codec) *amino.Codec {
+	cdc.RegisterConcrete(MsgSetFee{}, "shareledger/fee/MsgSetFee", nil)
+	return cdc
+}
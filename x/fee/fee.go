@@ -12,80 +12,153 @@ import (
 	"github.com/sharering/shareledger/x/auth"
 	"github.com/sharering/shareledger/x/bank"
 	"github.com/sharering/shareledger/x/exchange"
+	pKeeper "github.com/sharering/shareledger/x/pos/keeper"
 
 	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
 )
 
-type FeeHandler func(sdk.Context, sdkTypes.Result) (sdk.Result, bool)
+// FeeHandler - charges the fee of *msg* for the result of its handler
+type FeeHandler func(sdk.Context, sdk.Msg, sdkTypes.Result) (sdk.Result, bool)
 
-func NewFeeHandler(am auth.AccountMapper, exchangeKey *sdk.KVStoreKey) FeeHandler {
+func NewFeeHandler(feeKeeper Keeper, keeper bank.Keeper, posKeeper pKeeper.Keeper, exchangeKey *sdk.KVStoreKey) FeeHandler {
 	return func(
 		ctx sdk.Context,
+		msg sdk.Msg,
 		result sdkTypes.Result,
 	) (_ sdk.Result, abort bool) {
-		// Several tx don't return fee
+		// A failed message writes nothing, fees included.
+		// The gas of the ante handler was charged by NewAnteHandler.
+		if !result.IsOK() {
+			return result.CosmosResult(), false
+		}
+
+		// the fee of the message in the fee schedule is the minimum, several messages have none.
+		// Both are in FEE_DENOM, the denom gas is priced in.
+		txFee := types.NewCoin(constants.FEE_DENOM, feeKeeper.GetMsgFee(ctx, msg).Amount)
 
-		if result.FeeDenom == "" && result.FeeAmount == 0 {
+		// Gas used since the previous message of the tx, or since the ante handler for the first one,
+		// and the fixed cost of charging it
+		var gasUsed uint64
+		if gas := auth.GetTxGas(ctx); gas != nil {
+			ctx.GasMeter().ConsumeGas(constants.GAS_PER_FEE_CHARGE, "feeCharge")
+			gasUsed = gas.Charge(ctx.GasMeter().GasConsumed())
+
+			if gasFee := gas.Fee(gasUsed); gasFee.GT(txFee) {
+				txFee = gasFee
+			}
+		}
+
+		if txFee.IsZero() {
 			// if everything succeed, original result
 			result.Tags = result.Tags.
 				AppendTag(FeeDenom, constants.FEE_DENOM).
-				AppendTag(FeeAmount, strconv.FormatInt(int64(constants.NONE), 10)) 
+				AppendTag(FeeAmount, strconv.FormatInt(int64(constants.NONE), 10))
 			return result.CosmosResult(), false
 		}
 
-		txFee := types.NewCoin(result.FeeDenom, result.FeeAmount)
+		if err := chargeFee(unmetered(ctx), keeper, posKeeper, exchangeKey, txFee); err != nil {
+			return err.Result(), true
+		}
+
+		// if everything succeed, original result
+		result.Tags = result.Tags.
+			AppendTag(FeeDenom, txFee.Denom).
+			AppendTag(FeeAmount, txFee.Amount.String()).
+			AppendTag(GasUsed, strconv.FormatUint(gasUsed, 10))
+
+		return result.CosmosResult(), false
+	}
+
+}
+
+// NewAnteHandler - charges the gas used by *ante* at the gas price of the transaction.
+// What the ante handler writes is kept when messages fail, so failed transactions pay for it too.
+func NewAnteHandler(ante sdk.AnteHandler, keeper bank.Keeper, posKeeper pKeeper.Keeper, exchangeKey *sdk.KVStoreKey) sdk.AnteHandler {
+	return func(
+		ctx sdk.Context, tx sdk.Tx, simulate bool,
+	) (_ sdk.Context, _ sdk.Result, abort bool) {
+		newCtx, result, abort := ante(ctx, tx, simulate)
+		if abort {
+			return newCtx, result, abort
+		}
 
-		keeper := bank.NewKeeper(am)
+		gas := auth.GetTxGas(newCtx)
+		if gas == nil {
+			return newCtx, result, false
+		}
 
-		signer := auth.GetSigner(ctx).GetAddress()
+		// messages are charged for the gas used from here
+		newCtx.GasMeter().ConsumeGas(constants.GAS_PER_FEE_CHARGE, "feeCharge")
+		gasFee := gas.Fee(gas.Charge(newCtx.GasMeter().GasConsumed()))
+		if gasFee.IsZero() {
+			return newCtx, result, false
+		}
 
-		// abort due to fee has invalid denom or negative amount
-		if !(txFee.HasValidDenom() && txFee.IsNotNegative()) {
-			return sdk.ErrInternal(fmt.Sprintf(constants.INVALID_TX_FEE, txFee)).Result(),
-				true
+		if err := chargeFee(unmetered(newCtx), keeper, posKeeper, exchangeKey, gasFee); err != nil {
+			return newCtx, err.Result(), true
 		}
 
-		signerCoins := keeper.GetCoins(ctx, signer)
+		return newCtx, result, false
+	}
+}
 
-		// if Account is less than txFee
-		if signerCoins.LT(txFee) {
+// unmetered - *ctx* without gas limit. Fees are charged once the gas is counted,
+// their own cost is the GAS_PER_FEE_CHARGE metered beforehand.
+func unmetered(ctx sdk.Context) sdk.Context {
+	return ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+}
 
-			deltaCoins := signerCoins.Minus(txFee)
-			deltaCoin := deltaCoins.GetCoin(txFee.Denom).Neg()
+// chargeFee - subtract *txFee* from the signer and add it to the fee pool.
+// A signer short of FEE_DENOM buys the difference from the reserve.
+func chargeFee(ctx sdk.Context, keeper bank.Keeper, posKeeper pKeeper.Keeper, exchangeKey *sdk.KVStoreKey, txFee types.Coin) sdk.Error {
+	signer := auth.GetSigner(ctx).GetAddress()
 
-			exchangeKeeper := exchange.NewKeeper(exchangeKey, keeper)
+	// abort due to fee has invalid denom or negative amount
+	if !(txFee.HasValidDenom() && txFee.IsNotNegative()) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.INVALID_TX_FEE, txFee))
+	}
 
-			err := exchangeKeeper.BuyCoin(
-				ctx,
-				signer,
-				utils.StringToAddress(constants.DEFAULT_RESERVE),
-				constants.EXCHANGABLE_FEE_DENOM,
-				result.FeeDenom,
-				deltaCoin.Amount, // only buy the difference
-			)
+	// fees of a transaction signed under a grant are spent from the grant
+	if err := keeper.SpendGrant(ctx, txFee); err != nil {
+		return err
+	}
 
-			if err != nil {
-				return sdk.ErrInternal(fmt.Sprintf(constants.INSUFFICIENT_BALANCE, err)).Result(),
-					true
-			}
+	signerCoins := keeper.GetCoins(ctx, signer)
 
-		}
+	// if Account is less than txFee
+	if signerCoins.LT(txFee) {
+
+		deltaCoins := signerCoins.Minus(txFee)
+		deltaCoin := deltaCoins.GetCoin(txFee.Denom).Neg()
+
+		exchangeKeeper := exchange.NewKeeper(exchangeKey, keeper)
 
-		// Subtract fee to tx signer
-		_, err := keeper.SubtractCoin(ctx, signer, txFee)
+		err := exchangeKeeper.BuyCoin(
+			ctx,
+			signer,
+			utils.StringToAddress(constants.DEFAULT_RESERVE),
+			constants.EXCHANGABLE_FEE_DENOM,
+			txFee.Denom,
+			deltaCoin.Amount, // only buy the difference
+		)
 
-		// Insufficient coin
 		if err != nil {
-			return sdk.ErrInternal(fmt.Sprintf(constants.INSUFFICIENT_BALANCE, err)).Result(),
-				true
+			return sdk.ErrInternal(fmt.Sprintf(constants.INSUFFICIENT_BALANCE, err))
 		}
 
-		// if everything succeed, original result
-		result.Tags = result.Tags.
-			AppendTag(FeeDenom, result.FeeDenom).
-			AppendTag(FeeAmount, strconv.FormatInt(result.FeeAmount, 10))
+	}
 
-		return result.CosmosResult(), false
+	// Subtract fee to tx signer
+	_, err := keeper.SubtractCoin(ctx, signer, txFee)
+
+	// Insufficient coin
+	if err != nil {
+		return sdk.ErrInternal(fmt.Sprintf(constants.INSUFFICIENT_BALANCE, err))
 	}
 
+	// the fee goes to the fee pool, it is paid to validators and delegators at EndBlock
+	keeper.SubtractSupply(ctx, txFee)
+	posKeeper.AddFee(ctx, txFee)
+
+	return nil
 }
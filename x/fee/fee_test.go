@@ -0,0 +1,178 @@
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/bank/messages"
+	pKeeper "github.com/sharering/shareledger/x/pos/keeper"
+)
+
+type testKeepers struct {
+	fee         Keeper
+	bank        bank.Keeper
+	pos         pKeeper.Keeper
+	am          auth.AccountMapper
+	exchangeKey *sdk.KVStoreKey
+}
+
+func setupTestFees(t *testing.T) (sdk.Context, testKeepers) {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	posKey := sdk.NewKVStoreKey(constants.STORE_POS)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+	feeKey := sdk.NewKVStoreKey(constants.STORE_FEE)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, bankKey, posKey, exchangeKey, feeKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	bk := bank.NewKeeper(am, bankKey, cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000000, 0)}, false, log.NewNopLogger())
+	return ctx, testKeepers{
+		fee:         NewKeeper(feeKey, cdc),
+		bank:        bk,
+		pos:         pKeeper.NewKeeper(posKey, bk, cdc),
+		am:          am,
+		exchangeKey: exchangeKey,
+	}
+}
+
+// stands for the ante handler of auth, using *gas* before the messages run
+func testAnte(account auth.BaseAccount, gas uint64) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(constants.GAS_MAX_LIMIT))
+		ctx.GasMeter().ConsumeGas(gas, "test")
+
+		ctx = auth.WithSigners(ctx, account)
+		ctx = auth.WithTxGas(ctx, auth.NewTxGas(constants.GAS_MIN_PRICE))
+		return ctx, sdk.Result{}, false
+	}
+}
+
+func TestAnteHandlerChargesGas(t *testing.T) {
+	ctx, k := setupTestFees(t)
+
+	acc := auth.NewSHRAccountWithAddress(testAccount)
+	acc.SetCoins(types.Coins{types.NewCoin(constants.FEE_DENOM, 10)})
+	k.am.SetAccount(ctx, acc)
+	k.bank.AddSupply(ctx, types.NewCoin(constants.FEE_DENOM, 10))
+
+	// 2*10^6 gas at 10^-6 per gas, with the cost of charging it
+	ante := NewAnteHandler(testAnte(acc, 2000000-constants.GAS_PER_FEE_CHARGE), k.bank, k.pos, k.exchangeKey)
+	ctx, res, abort := ante(ctx, nil, false)
+	if abort {
+		t.Fatalf("Ante handler should not abort. %s", res.Log)
+	}
+
+	balance := func() types.Coin { return k.bank.GetCoins(ctx, testAccount).GetCoin(constants.FEE_DENOM) }
+
+	if !balance().Equal(types.NewCoin(constants.FEE_DENOM, 8)) {
+		t.Errorf("Gas of the ante handler should be charged, balance %s.", balance().String())
+	}
+
+	feeHandler := NewFeeHandler(k.fee, k.bank, k.pos, k.exchangeKey)
+	msg := messages.NewMsgSend(testAccount, types.NewCoin("SHRP", 1))
+
+	// a failed message is not charged, the ante gas already is
+	failed := sdkTypes.NewResult(sdk.ErrInternal("failed").Result())
+	if _, abort := feeHandler(ctx, msg, failed); abort {
+		t.Fatalf("Fee handler should not abort a failed message.")
+	}
+	if !balance().Equal(types.NewCoin(constants.FEE_DENOM, 8)) {
+		t.Errorf("Failed message should not be charged, balance %s.", balance().String())
+	}
+
+	// the ante gas is not charged twice, the message pays its fee
+	if _, abort := feeHandler(ctx, msg, sdkTypes.NewResult(sdk.Result{})); abort {
+		t.Fatalf("Fee handler should not abort.")
+	}
+	if !balance().Equal(types.NewCoin(constants.FEE_DENOM, 7)) {
+		t.Errorf("Message should pay its fee only, balance %s.", balance().String())
+	}
+
+	if pool := k.pos.GetFeePool(ctx); !pool.GetCoin(constants.FEE_DENOM).Equal(types.NewCoin(constants.FEE_DENOM, 3)) {
+		t.Errorf("Fee pool should hold the fees charged, got %s.", pool.String())
+	}
+
+	if err := k.bank.CheckSupply(ctx); err != nil {
+		t.Errorf("Fees should leave the supply. %s", err)
+	}
+}
+
+func TestFeeChargeMeteredUpFront(t *testing.T) {
+	ctx, k := setupTestFees(t)
+
+	acc := auth.NewSHRAccountWithAddress(testAccount)
+	acc.SetCoins(types.Coins{types.NewCoin(constants.FEE_DENOM, 10)})
+	k.am.SetAccount(ctx, acc)
+	k.bank.AddSupply(ctx, types.NewCoin(constants.FEE_DENOM, 10))
+
+	ante := NewAnteHandler(testAnte(acc, 1000000), k.bank, k.pos, k.exchangeKey)
+	ctx, res, abort := ante(ctx, nil, false)
+	if abort {
+		t.Fatalf("Ante handler should not abort. %s", res.Log)
+	}
+
+	// charging writes accounts, supply and fee pool, only its fixed cost is metered
+	if consumed := ctx.GasMeter().GasConsumed(); consumed != 1000000+constants.GAS_PER_FEE_CHARGE {
+		t.Errorf("Ante handler should meter %d gas, metered %d.", 1000000+constants.GAS_PER_FEE_CHARGE, consumed)
+	}
+
+	feeHandler := NewFeeHandler(k.fee, k.bank, k.pos, k.exchangeKey)
+	msg := messages.NewMsgSend(testAccount, types.NewCoin("SHRP", 1))
+
+	// the message uses more gas than its fee covers
+	ctx.GasMeter().ConsumeGas(2000000, "test")
+
+	res, abort = feeHandler(ctx, msg, sdkTypes.NewResult(sdk.Result{}))
+	if abort {
+		t.Fatalf("Fee handler should not abort. %s", res.Log)
+	}
+
+	// what charging used is not billed to the next message
+	consumed := ctx.GasMeter().GasConsumed()
+	gas := auth.GetTxGas(ctx)
+	if left := gas.Charge(consumed); left != 0 {
+		t.Errorf("Gas of the fee charge should not be left for the next message, %d left.", left)
+	}
+
+	// the message paid its gas and the fixed cost of charging it, read off the meter
+	gasFee := gas.Fee(consumed - 1000000 - constants.GAS_PER_FEE_CHARGE)
+	if gasFee.LT(gas.Fee(2000000 + constants.GAS_PER_FEE_CHARGE)) {
+		t.Errorf("Message should pay at least its gas and the cost of charging it, paid %s.", gasFee.String())
+	}
+
+	anteFee := gas.Fee(1000000 + constants.GAS_PER_FEE_CHARGE)
+	expected := types.NewCoin(constants.FEE_DENOM, 10).Minus(anteFee).Minus(gasFee)
+	if balance := k.bank.GetCoins(unmetered(ctx), testAccount).GetCoin(constants.FEE_DENOM); !balance.Equal(expected) {
+		t.Errorf("Balance should be %s, got %s.", expected.String(), balance.String())
+	}
+}
@@ -0,0 +1,26 @@
+package fee
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+)
+
+// GenesisState - fee schedule at genesis
+type GenesisState struct {
+	Schedule []types.MsgFee `json:"schedule"`
+}
+
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) error {
+	for _, fee := range data.Schedule {
+		if err := k.SetFee(ctx, fee); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateGenesis - the fee of each message type is its level in constants.LEVELS
+func GenerateGenesis() GenesisState {
+	return GenesisState{Schedule: DefaultSchedule()}
+}
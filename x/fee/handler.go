@@ -0,0 +1,48 @@
+package fee
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+
+	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
+)
+
+func NewHandler(k Keeper) sdkTypes.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdkTypes.Result {
+		constants.LOGGER.Info(
+			"Msg for Fee Module",
+			"type", reflect.TypeOf(msg),
+			"msg", msg,
+		)
+
+		switch msg := msg.(type) {
+		case MsgSetFee:
+			return sdkTypes.NewResult(handleSetFee(ctx, k, msg))
+		default:
+			errMsg := "Unrecognized Fee Msg type" + reflect.TypeOf(msg).Name()
+			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
+		}
+	}
+}
+
+// The fee schedule is changed by the reserve
+func handleSetFee(ctx sdk.Context, k Keeper, msg MsgSetFee) sdk.Result {
+	if !utils.IsValidReserve(auth.GetSigner(ctx).GetAddress()) {
+		return sdk.ErrInternal(fmt.Sprintf(constants.RES_RESERVE_ONLY)).Result()
+	}
+
+	if err := k.SetFee(ctx, msg.Fee()); err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log:  msg.Fee().String(),
+		Tags: msg.Tags(),
+	}
+}
@@ -0,0 +1,111 @@
+package fee
+
+import (
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+)
+
+type Keeper struct {
+	storeKey sdk.StoreKey // the fee schedule
+	cdc      *amino.Codec
+}
+
+func NewKeeper(key sdk.StoreKey, cdc *amino.Codec) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+	}
+}
+
+// GetFee returns the entry of the fee schedule for *msgType* of *route*
+func (k Keeper) GetFee(ctx sdk.Context, route string, msgType string) (fee types.MsgFee, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetFeeKey(route, msgType))
+	if bz == nil {
+		return fee, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &fee)
+	return fee, true
+}
+
+// SetFee - add or replace an entry of the fee schedule.
+// An amount of 0 is kept, the message is then charged nothing instead of its level.
+func (k Keeper) SetFee(ctx sdk.Context, fee types.MsgFee) error {
+	if err := fee.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetFeeKey(fee.Route, fee.MsgType), k.cdc.MustMarshalBinaryLengthPrefixed(fee))
+	return nil
+}
+
+// GetMsgFee returns the fee of *msg*, its entry in the fee schedule or else its level in constants.LEVELS
+func (k Keeper) GetMsgFee(ctx sdk.Context, msg sdk.Msg) types.MsgFee {
+	route, msgType := msg.Route(), utils.GetMsgType(msg)
+
+	if fee, found := k.GetFee(ctx, route, msgType); found {
+		return fee
+	}
+	return defaultFee(route, msgType)
+}
+
+// GetFeeSchedule returns the fee of every message type with a level or an entry in the fee schedule
+func (k Keeper) GetFeeSchedule(ctx sdk.Context) []types.MsgFee {
+	fees := make(map[string]types.MsgFee)
+	for _, fee := range DefaultSchedule() {
+		fees[fee.Route+"/"+fee.MsgType] = fee
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, FeeScheduleKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var fee types.MsgFee
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &fee)
+		fees[fee.Route+"/"+fee.MsgType] = fee
+	}
+
+	return sortSchedule(fees)
+}
+
+// DefaultSchedule - the fee of each message type of constants.LEVELS
+func DefaultSchedule() []types.MsgFee {
+	fees := make(map[string]types.MsgFee)
+	for key := range constants.LEVELS {
+		parts := strings.SplitN(key, "/", 2)
+		fees[key] = defaultFee(parts[0], parts[1])
+	}
+	return sortSchedule(fees)
+}
+
+// fee of a message type without entry in the fee schedule, nothing for a message type without level
+func defaultFee(route string, msgType string) types.MsgFee {
+	level := constants.LEVELS[route+"/"+msgType]
+	return types.NewMsgFee(route, msgType, int64(constants.FEE_LEVELS[level]), constants.FEE_DENOM)
+}
+
+// sorted by route and type name, so that schedules are the same on every node
+func sortSchedule(fees map[string]types.MsgFee) []types.MsgFee {
+	keys := make([]string, 0, len(fees))
+	for key := range fees {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	schedule := make([]types.MsgFee, 0, len(keys))
+	for _, key := range keys {
+		schedule = append(schedule, fees[key])
+	}
+	return schedule
+}
@@ -0,0 +1,92 @@
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/bank/messages"
+)
+
+var testAccount = sdk.AccAddress([]byte("account_____________"))
+
+func setupTestKeeper(t *testing.T) (sdk.Context, Keeper) {
+	constants.LOGGER = log.NewNopLogger()
+
+	feeKey := sdk.NewKVStoreKey(constants.STORE_FEE)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(feeKey, sdk.StoreTypeIAVL, db)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000000, 0)}, false, log.NewNopLogger())
+	return ctx, NewKeeper(feeKey, amino.NewCodec())
+}
+
+func TestMsgFeeFallsBackToLevels(t *testing.T) {
+	ctx, k := setupTestKeeper(t)
+	msg := messages.NewMsgSend(testAccount, types.NewCoin("SHRP", 1))
+
+	// an empty fee schedule charges the level of the message
+	fee := k.GetMsgFee(ctx, msg)
+	want := int64(constants.FEE_LEVELS[constants.LEVELS["bank/MsgSend"]])
+	if fee.Amount != want || fee.Denom != constants.FEE_DENOM {
+		t.Errorf("Fee without entry should be %d%s, got %s.", want, constants.FEE_DENOM, fee.String())
+	}
+
+	// messages without level are charged nothing
+	if fee := k.GetMsgFee(ctx, NewMsgSetFee("bank", "MsgSend", 5, constants.FEE_DENOM)); fee.Amount != 0 {
+		t.Errorf("Fee of a message without level should be 0, got %s.", fee.String())
+	}
+}
+
+func TestSetFee(t *testing.T) {
+	ctx, k := setupTestKeeper(t)
+	msg := messages.NewMsgSend(testAccount, types.NewCoin("SHRP", 1))
+
+	if err := k.SetFee(ctx, types.NewMsgFee("bank", "MsgSend", 7, constants.FEE_DENOM)); err != nil {
+		t.Fatalf("Setting the fee failed. %s", err)
+	}
+	if fee := k.GetMsgFee(ctx, msg); fee.Amount != 7 {
+		t.Errorf("Fee should be the entry of the schedule, got %s.", fee.String())
+	}
+
+	// a fee of 0 is kept, it does not fall back to the level
+	if err := k.SetFee(ctx, types.NewMsgFee("bank", "MsgSend", 0, constants.FEE_DENOM)); err != nil {
+		t.Fatalf("Setting the fee failed. %s", err)
+	}
+	if fee := k.GetMsgFee(ctx, msg); fee.Amount != 0 {
+		t.Errorf("Fee set to 0 should charge nothing, got %s.", fee.String())
+	}
+
+	if err := k.SetFee(ctx, types.NewMsgFee("bank", "MsgSend", -1, constants.FEE_DENOM)); err == nil {
+		t.Errorf("Negative fees should be rejected.")
+	}
+
+	// gas is priced in FEE_DENOM, so are fees
+	if err := k.SetFee(ctx, types.NewMsgFee("bank", "MsgSend", 1, "SHRP")); err == nil {
+		t.Errorf("Fees in another denom than %s should be rejected.", constants.FEE_DENOM)
+	}
+
+	// the schedule holds every level, overridden by entries
+	schedule := k.GetFeeSchedule(ctx)
+	if len(schedule) != len(constants.LEVELS) {
+		t.Errorf("Schedule should have %d entries, got %d.", len(constants.LEVELS), len(schedule))
+	}
+	for _, fee := range schedule {
+		if fee.Route == "bank" && fee.MsgType == "MsgSend" && fee.Amount != 0 {
+			t.Errorf("Schedule should hold the entry set, got %s.", fee.String())
+		}
+	}
+}
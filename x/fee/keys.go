@@ -0,0 +1,13 @@
+package fee
+
+// nolint
+var (
+	// Keys for store prefixes
+	FeeScheduleKey = []byte{0x01} // prefix for each key to an entry of the fee schedule, by route and type name
+)
+
+// gets the key of the fee of a message type of a route
+// VALUE: types.MsgFee
+func GetFeeKey(route string, msgType string) []byte {
+	return append(FeeScheduleKey, []byte(route+"/"+msgType)...)
+}
@@ -0,0 +1,73 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+var _ sdk.Msg = MsgSetFee{}
+
+// MsgSetFee - set the fee of *MsgType* of *MsgRoute*, an amount of 0 charges nothing
+type MsgSetFee struct {
+	MsgRoute string `json:"route"`    // e.g. booking, exchangerate
+	MsgType  string `json:"msg_type"` // e.g. MsgBook, MsgSend
+	Amount   int64  `json:"amount"`
+	Denom    string `json:"denom"`
+}
+
+func NewMsgSetFee(route string, msgType string, amount int64, denom string) MsgSetFee {
+	return MsgSetFee{
+		MsgRoute: route,
+		MsgType:  msgType,
+		Amount:   amount,
+		Denom:    denom,
+	}
+}
+
+func (msg MsgSetFee) Route() string {
+	return constants.MESSAGE_FEE
+}
+
+func (msg MsgSetFee) Type() string {
+	return constants.MESSAGE_FEE
+}
+
+func (msg MsgSetFee) ValidateBasic() sdk.Error {
+	if err := msg.Fee().Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+func (msg MsgSetFee) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg MsgSetFee) String() string {
+	return fmt.Sprintf("Fee/MsgSetFee{Route: %s, MsgType: %s, Amount: %d, Denom: %s}",
+		msg.MsgRoute, msg.MsgType, msg.Amount, msg.Denom)
+}
+
+func (msg MsgSetFee) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgSetFee) Tags() sdk.Tags {
+	return sdk.NewTags(Route, msg.MsgRoute).
+		AppendTag(MsgType, msg.MsgType).
+		AppendTag(Event, FeeSet)
+}
+
+// Fee - entry of the fee schedule set by this message
+func (msg MsgSetFee) Fee() types.MsgFee {
+	return types.NewMsgFee(msg.MsgRoute, msg.MsgType, msg.Amount, msg.Denom)
+}
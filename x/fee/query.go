@@ -0,0 +1,35 @@
+package fee
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+)
+
+// query endpoints supported by fee querier
+const (
+	QuerySchedule = "schedule"
+)
+
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySchedule:
+			return querySchedule(ctx, k, cdc)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown fee query endpoint")
+		}
+	}
+}
+
+func querySchedule(ctx sdk.Context, k Keeper, cdc *amino.Codec) (res []byte, err sdk.Error) {
+	res, errRes := cdc.MarshalJSON(k.GetFeeSchedule(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.MsgFee"))
+	}
+	return res, nil
+}
@@ -4,4 +4,11 @@ var (
 	// Key - String type
 	FeeAmount = "FeeAmount"
 	FeeDenom  = "FeeDenom"
+	GasUsed   = "GasUsed"
+	Route     = "Route"
+	MsgType   = "MsgType"
+	Event     = "Event"
+
+	// Value - String type
+	FeeSet = "FeeSet" // entry of the fee schedule added, replaced or removed
 )
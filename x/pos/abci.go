@@ -24,11 +24,14 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper, proposerAddress []byte) []abci
 			panic(posTypes.ErrNoValidatorFound(posTypes.DefaultCodespace).Error())
 		}
 
+		// fees collected during the block are shared with the block reward
+		fees := k.TakeFees(ctx)
+
 		vdi, err := k.UpdateBlockReward(
 			ctx,
 			validator.Owner,
 			validator.CommissionRate,
-			types.NewPOSCoin(constants.POS_BLOCK_REWARD),
+			types.NewPOSCoin(constants.POS_BLOCK_REWARD).Plus(fees),
 		)
 
 		if err != nil {
@@ -36,6 +39,7 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper, proposerAddress []byte) []abci
 		}
 
 		constants.LOGGER.Info(fmt.Sprintf("Proposer %X", vdi.ValidatorAddr),
+			"Fees", fees.String(),
 			"RewardAccum", vdi.RewardAccum.String(),
 			"Commission", vdi.Commission.String(),
 			"WithdrawHeight", vdi.WithdrawalHeight,
@@ -81,6 +81,8 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt types.
 		if err != nil {
 			return
 		}
+		// bonded coins leave the supply held by accounts until unbonded
+		k.bankKeeper.SubtractSupply(ctx, bondAmt)
 	}
 
 	validator, newShares = k.AddValidatorTokensAndShares(ctx, validator, bondAmt.Amount)
@@ -198,6 +200,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 		if err != nil {
 			return err
 		}
+		k.bankKeeper.AddSupply(ctx, balance)
 		return nil
 	}
 
@@ -290,6 +293,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAd
 	if err != nil {
 		return err
 	}
+	k.bankKeeper.AddSupply(ctx, ubd.Balance)
 	k.RemoveUnbondingDelegation(ctx, ubd)
 	return nil
 }
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// GetFeePool returns the transaction fees collected and not yet distributed
+func (k Keeper) GetFeePool(ctx sdk.Context) types.Coins {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(FeePoolKey)
+	if b == nil {
+		return types.NewDefaultCoins()
+	}
+
+	var pool types.Coins
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &pool)
+	return pool
+}
+
+func (k Keeper) setFeePool(ctx sdk.Context, pool types.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(pool)
+	store.Set(FeePoolKey, b)
+}
+
+// AddFee - put a fee charged to a transaction signer in the fee pool
+func (k Keeper) AddFee(ctx sdk.Context, fee types.Coin) {
+	pool := k.GetFeePool(ctx)
+	k.setFeePool(ctx, pool.Plus(fee))
+}
+
+// TakeFees - empty the fee pool of the staking denom, to be distributed like a block reward.
+// Fees of other denoms stay in the pool.
+func (k Keeper) TakeFees(ctx sdk.Context) types.Coin {
+	pool := k.GetFeePool(ctx)
+	fees := pool.GetCoin(constants.POS_DENOM)
+
+	k.setFeePool(ctx, pool.Minus(fees))
+	return fees
+}
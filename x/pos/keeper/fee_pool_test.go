@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+)
+
+func setupTestKeeper(t *testing.T) (sdk.Context, Keeper) {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	posKey := sdk.NewKVStoreKey(constants.STORE_POS)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, bankKey, posKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000000, 0)}, false, log.NewNopLogger())
+	return ctx, NewKeeper(posKey, bank.NewKeeper(am, bankKey, cdc), cdc)
+}
+
+func TestFeePoolAccrual(t *testing.T) {
+	ctx, k := setupTestKeeper(t)
+
+	if !k.GetFeePool(ctx).IsZero() {
+		t.Fatalf("Fee pool should start empty, got %s.", k.GetFeePool(ctx).String())
+	}
+
+	// fees of every transaction of a block add up
+	k.AddFee(ctx, types.NewCoin(constants.POS_DENOM, 3))
+	k.AddFee(ctx, types.NewCoin(constants.POS_DENOM, 2))
+	k.AddFee(ctx, types.NewCoin("SHRP", 4))
+
+	pool := k.GetFeePool(ctx)
+	if !pool.GetCoin(constants.POS_DENOM).Equal(types.NewCoin(constants.POS_DENOM, 5)) {
+		t.Errorf("Fee pool should hold 5%s, got %s.", constants.POS_DENOM, pool.String())
+	}
+
+	// the staking denom is distributed, other denoms stay
+	fees := k.TakeFees(ctx)
+	if !fees.Equal(types.NewCoin(constants.POS_DENOM, 5)) {
+		t.Errorf("Fees taken should be 5%s, got %s.", constants.POS_DENOM, fees.String())
+	}
+
+	pool = k.GetFeePool(ctx)
+	if !pool.GetCoin(constants.POS_DENOM).IsZero() || !pool.GetCoin("SHRP").Equal(types.NewCoin("SHRP", 4)) {
+		t.Errorf("Fee pool should keep 4SHRP only, got %s.", pool.String())
+	}
+
+	// nothing is distributed twice
+	if fees := k.TakeFees(ctx); !fees.IsZero() {
+		t.Errorf("Fees taken from an emptied pool should be 0, got %s.", fees.String())
+	}
+}
@@ -28,6 +28,7 @@ var (
 	RedelegationByValDstIndexKey     = []byte{0x0C} // prefix for each key for an redelegation, by destination validator operator
 	ValidatorDistKey                 = []byte{0x0D} // prefix for each key for validator distribution information
 	ValidatorsTDMAddrKey             = []byte{0x0E} // prefix for mapping from TDM address to Shareledger address
+	FeePoolKey                       = []byte{0x0F} // key for the fees collected and not yet distributed
 	// Last* values are const during a block.
 	LastValidatorPowerKey = []byte{0x11} // prefix for each key to a validator index, for bonded validators
 	LastTotalPowerKey     = []byte{0x12} // prefix for the total power
@@ -250,19 +250,12 @@ func (k Keeper) WithdrawDelReward(
 	coins := k.bankKeeper.GetCoins(ctx, delegatorAddr)
 	fmt.Printf("Before update balance: %v\n", coins)
 
-	// update balance of delegator
+	// update balance of delegator, rewards are new coins
 	after, err := k.bankKeeper.AddCoin(
 		ctx,
 		delegatorAddr,
 		rewardCoin,
 	)
-	if err != nil {
-		return vdi,
-			types.NewZeroPOSCoin(),
-			sdk.ErrInternal(fmt.Sprintf(constants.POS_WITHDRAWAL_ERROR, err.Error()))
-	}
-
-	err = k.bankKeeper.SetCoins(ctx, validatorAddr, after)
 	fmt.Printf("After update balance %v\n", after)
 
 	if err != nil {
@@ -271,6 +264,8 @@ func (k Keeper) WithdrawDelReward(
 			sdk.ErrInternal(fmt.Sprintf(constants.POS_WITHDRAWAL_ERROR, err.Error()))
 	}
 
+	k.bankKeeper.AddSupply(ctx, rewardCoin)
+
 	return vdi, rewardCoin, nil
 
 }
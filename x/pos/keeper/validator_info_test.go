@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/types"
+	posTypes "github.com/sharering/shareledger/x/pos/type"
+)
+
+func TestWithdrawDelReward(t *testing.T) {
+	ctx, k := setupTestKeeper(t)
+
+	validatorAddr := sdk.AccAddress([]byte("validator___________"))
+	delegatorAddr := sdk.AccAddress([]byte("delegator___________"))
+
+	pubKey, _ := types.GenerateKeyPair()
+	validator := posTypes.NewValidator(validatorAddr, pubKey, posTypes.NewDescription("validator", "", "", ""))
+	validator.DelegatorShares = types.NewDec(4)
+	k.SetValidator(ctx, validator)
+
+	vdi := posTypes.NewValidatorDistInfo(validatorAddr, ctx.BlockHeight())
+	vdi.RewardAccum = types.NewPOSCoin(8)
+	k.SetValidatorDistInfo(ctx, vdi)
+
+	k.SetDelegation(ctx, posTypes.Delegation{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+		Shares:        types.NewDec(1),
+		Height:        ctx.BlockHeight(),
+		RewardAccum:   types.NewZeroPOSCoin(),
+	})
+
+	for addr, amount := range map[string]int64{string(validatorAddr): 7, string(delegatorAddr): 3} {
+		coin := types.NewPOSCoin(amount)
+		if _, err := k.bankKeeper.AddCoin(ctx, sdk.AccAddress(addr), coin); err != nil {
+			t.Fatalf("Funding failed. %s", err)
+		}
+		k.bankKeeper.AddSupply(ctx, coin)
+	}
+
+	_, reward, err := k.WithdrawDelReward(ctx, validatorAddr, delegatorAddr)
+	if err != nil {
+		t.Fatalf("Withdrawing reward failed. %s", err)
+	}
+
+	// a quarter of the shares earns a quarter of the accumulated reward
+	if !reward.Equal(types.NewPOSCoin(2)) {
+		t.Errorf("Delegator should withdraw 2, got %s.", reward.String())
+	}
+
+	if balance := k.bankKeeper.GetCoins(ctx, delegatorAddr); !balance.Equal(types.NewPOSCoin(5)) {
+		t.Errorf("Delegator should have 5, has %s.", balance.String())
+	}
+
+	// the reward is minted for the delegator, the validator balance is left alone
+	if balance := k.bankKeeper.GetCoins(ctx, validatorAddr); !balance.Equal(types.NewPOSCoin(7)) {
+		t.Errorf("Validator should keep 7, has %s.", balance.String())
+	}
+
+	if err := k.bankKeeper.CheckSupply(ctx); err != nil {
+		t.Errorf("Supply should count the reward. %s", err)
+	}
+
+	delegation, _ := k.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	if !delegation.RewardAccum.IsZero() {
+		t.Errorf("Withdrawn reward should be reset, got %s.", delegation.RewardAccum.String())
+	}
+}
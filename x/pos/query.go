@@ -26,6 +26,7 @@ const (
 	QueryPool                = "pool"
 	QueryParameters          = "parameters"
 	QueryValidatorDistInfo   = "validatorDistInfo"
+	QueryFeePool             = "feePool"
 )
 
 // creates a querier for staking REST endpoints
@@ -41,6 +42,8 @@ func NewQuerier(k keep.Keeper, cdc *amino.Codec) sdk.Querier {
 			return queryValidatorDistInfo(ctx, cdc, req, k)
 		case QueryDelegation:
 			return queryDelegation(ctx, cdc, req, k)
+		case QueryFeePool:
+			return queryFeePool(ctx, cdc, k)
 		/*
 			case QueryDelegator:
 				return queryDelegator(ctx, cdc, req, k)
@@ -191,6 +194,16 @@ func queryDelegation(
 	return res, nil
 }
 
+func queryFeePool(ctx sdk.Context, cdc *amino.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
+	res, errRes := cdc.MarshalJSON(k.GetFeePool(ctx))
+	if errRes != nil {
+		return nil,
+			sdk.ErrInternal(fmt.Sprintf(constants.POS_MARSHAL_ERROR, errRes.Error()))
+	}
+
+	return res, nil
+}
+
 /*
 func queryDelegator(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k keep.Keeper) (res []byte, err sdk.Error) {
 	var params QueryDelegatorParams
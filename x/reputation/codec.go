@@ -0,0 +1,11 @@
+package reputation
+
+import (
+	"github.com/tendermint/go-amino"
+	msg "github.com/sharering/shareledger/x/reputation/messages"
+)
+
+func RegisterCodec(cdc *amino.Codec) *amino.Codec {
+	cdc.RegisterConcrete(msg.MsgReview{}, "shareledger/reputation/MsgReview", nil)
+	return cdc
+}
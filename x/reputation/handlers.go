@@ -0,0 +1,56 @@
+package reputation
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/x/reputation/messages"
+	"github.com/sharering/shareledger/x/reputation/tags"
+
+	sdkTypes "github.com/sharering/shareledger/cosmos-wrapper/types"
+)
+
+func NewHandler(k Keeper) sdkTypes.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdkTypes.Result {
+
+		constants.LOGGER.Info(
+			"Msg for Reputation Module",
+			"type", reflect.TypeOf(msg),
+			"msg", msg,
+		)
+
+		var ret sdk.Result
+
+		switch msg := msg.(type) {
+		case messages.MsgReview:
+			ret = handleReview(ctx, k, msg)
+
+		default:
+			errMsg := fmt.Sprintf("Unrecognized trace Msg type: %v", reflect.TypeOf(msg).Name())
+			return sdkTypes.NewResult(sdk.ErrUnknownRequest(errMsg).Result())
+		}
+
+		// the fee handler charges the fee of the message
+		return sdkTypes.NewResult(ret)
+	}
+}
+
+func handleReview(ctx sdk.Context, k Keeper, msg messages.MsgReview) sdk.Result {
+
+	review, err := k.Review(ctx, msg)
+
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+
+	return sdk.Result{
+		Log: fmt.Sprintf("%s", review.String()),
+		Tags: msg.Tags().
+			AppendTag(tags.UUID, review.UUID).
+			AppendTag(tags.Reviewer, review.Reviewer.String()).
+			AppendTag(tags.Subject, review.Subject.String()),
+	}
+}
@@ -0,0 +1,158 @@
+package reputation
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/booking"
+	msg "github.com/sharering/shareledger/x/reputation/messages"
+)
+
+type Keeper struct {
+	storeKey      sdk.StoreKey   // key used to access the store from the context
+	bookingKeeper booking.Keeper // checks reviewed bookings
+	cdc           *amino.Codec
+}
+
+func NewKeeper(key sdk.StoreKey, bk booking.Keeper, cdc *amino.Codec) Keeper {
+	return Keeper{
+		storeKey:      key,
+		bookingKeeper: bk,
+		cdc:           cdc,
+	}
+}
+
+//-----------------------------------------------
+
+// GetReview returns the review of a booking posted by *reviewer*
+func (k Keeper) GetReview(ctx sdk.Context, bookingID string, reviewer sdk.AccAddress) (review types.Review, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(GetReviewKey(bookingID, reviewer))
+	if bz == nil {
+		return review, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &review)
+	return review, true
+}
+
+// GetReviews returns the reviews of a booking, at most one per party
+func (k Keeper) GetReviews(ctx sdk.Context, bookingID string) []types.Review {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetReviewsPrefix(bookingID))
+	defer iterator.Close()
+
+	reviews := []types.Review{}
+
+	for ; iterator.Valid(); iterator.Next() {
+		var review types.Review
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &review)
+		reviews = append(reviews, review)
+	}
+	return reviews
+}
+
+// GetAccountScore returns the aggregate of ratings received by an account
+func (k Keeper) GetAccountScore(ctx sdk.Context, addr sdk.AccAddress) types.Score {
+	return k.getScore(ctx, GetAccountScoreKey(addr))
+}
+
+// GetAssetScore returns the aggregate of ratings renters gave for an asset
+func (k Keeper) GetAssetScore(ctx sdk.Context, uuid string) types.Score {
+	return k.getScore(ctx, GetAssetScoreKey(uuid))
+}
+
+func (k Keeper) getScore(ctx sdk.Context, key []byte) (score types.Score) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(key)
+	if bz == nil {
+		return score
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &score)
+	return score
+}
+
+func (k Keeper) addRating(ctx sdk.Context, key []byte, rating int64) {
+	store := ctx.KVStore(k.storeKey)
+	score := k.getScore(ctx, key).Add(rating)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(score))
+}
+
+// Review - a party of a completed booking rates the other party, once.
+// A renter rating the owner also rates the asset.
+func (k Keeper) Review(ctx sdk.Context, msg msg.MsgReview) (types.Review, error) {
+
+	bk, found := k.bookingKeeper.GetBooking(ctx, msg.BookingID)
+	if !found {
+		return types.Review{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			msg.BookingID,
+			constants.STORE_BOOKING)
+	}
+
+	// a completed booking stays flagged as such even once its deposit is disputed
+	if !bk.IsCompleted {
+		return types.Review{}, fmt.Errorf(constants.REVIEW_BOOKING_NOT_COMPLETED,
+			bk.BookingID)
+	}
+
+	asset, found := k.bookingKeeper.GetAsset(ctx, bk.UUID)
+	if !found {
+		return types.Review{}, fmt.Errorf(constants.ERROR_STORE_NOT_FOUND,
+			bk.UUID,
+			constants.STORE_ASSET)
+	}
+
+	reviewer := auth.GetSigner(ctx).GetAddress()
+
+	var subject sdk.AccAddress
+
+	switch {
+	case bytes.Equal(bk.Renter, asset.Creator):
+		// nobody rates itself
+	case bytes.Equal(reviewer, bk.Renter):
+		subject = asset.Creator
+	case bytes.Equal(reviewer, asset.Creator):
+		subject = bk.Renter
+	}
+
+	if subject == nil {
+		return types.Review{}, fmt.Errorf(constants.REVIEW_NOT_PARTY,
+			bk.BookingID,
+			utils.ByteToString(reviewer))
+	}
+
+	if _, found := k.GetReview(ctx, bk.BookingID, reviewer); found {
+		return types.Review{}, fmt.Errorf(constants.REVIEW_ALREADY_EXISTS,
+			bk.BookingID,
+			utils.ByteToString(reviewer))
+	}
+
+	review := types.NewReview(bk.BookingID,
+		bk.UUID,
+		reviewer,
+		subject,
+		msg.Rating,
+		msg.ContentHash,
+		ctx.BlockHeight())
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetReviewKey(bk.BookingID, reviewer), k.cdc.MustMarshalBinaryLengthPrefixed(review))
+
+	k.addRating(ctx, GetAccountScoreKey(subject), msg.Rating)
+
+	if bytes.Equal(reviewer, bk.Renter) {
+		k.addRating(ctx, GetAssetScoreKey(bk.UUID), msg.Rating)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,183 @@
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+	"github.com/sharering/shareledger/utils"
+	"github.com/sharering/shareledger/x/auth"
+	"github.com/sharering/shareledger/x/bank"
+	"github.com/sharering/shareledger/x/booking"
+	bookingMsg "github.com/sharering/shareledger/x/booking/messages"
+	"github.com/sharering/shareledger/x/exchange"
+	msg "github.com/sharering/shareledger/x/reputation/messages"
+)
+
+const (
+	testNow  = int64(1000000)
+	testHour = int64(60 * 60)
+)
+
+var (
+	testOwner    = sdk.AccAddress([]byte("owner_______________"))
+	testRenter   = sdk.AccAddress([]byte("renter______________"))
+	testStranger = sdk.AccAddress([]byte("stranger____________"))
+)
+
+type testInput struct {
+	ctx sdk.Context
+	k   Keeper
+	bk  bank.Keeper
+	am  auth.AccountMapper
+}
+
+func setupTestInput(t *testing.T) testInput {
+	constants.LOGGER = log.NewNopLogger()
+
+	authKey := sdk.NewKVStoreKey(constants.STORE_AUTH)
+	assetKey := sdk.NewKVStoreKey(constants.STORE_ASSET)
+	bookingKey := sdk.NewKVStoreKey(constants.STORE_BOOKING)
+	bankKey := sdk.NewKVStoreKey(constants.STORE_BANK)
+	exchangeKey := sdk.NewKVStoreKey(constants.STORE_EXCHANGE)
+	reputationKey := sdk.NewKVStoreKey(constants.STORE_REPUTATION)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []*sdk.KVStoreKey{authKey, assetKey, bookingKey, bankKey, exchangeKey, reputationKey} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("Loading stores failed. %s", err)
+	}
+
+	cdc := amino.NewCodec()
+	cdc.RegisterInterface((*auth.BaseAccount)(nil), nil)
+	cdc.RegisterConcrete(auth.SHRAccount{}, "shareledger/SHRAccount", nil)
+	cdc.RegisterInterface((*types.PubKey)(nil), nil)
+	cdc.RegisterConcrete(types.PubKeySecp256k1{}, "shareledger/PubSecp256k1", nil)
+
+	am := auth.NewAccountMapper(cdc, authKey, &auth.SHRAccount{})
+	bk := bank.NewKeeper(am, bankKey, cdc)
+	ek := exchange.NewKeeper(exchangeKey, bk)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(testNow, 0)}, false, log.NewNopLogger())
+
+	asset := types.NewAsset("asset", testOwner, nil, true, 10)
+	if err := utils.Store(ctx.KVStore(assetKey), []byte(asset.UUID), asset); err != nil {
+		t.Fatalf("Storing asset failed. %s", err)
+	}
+
+	return testInput{
+		ctx: ctx,
+		k:   NewKeeper(reputationKey, booking.NewKeeper(bookingKey, assetKey, bk, ek, cdc), cdc),
+		bk:  bk,
+		am:  am,
+	}
+}
+
+// signedBy returns the context of a transaction signed by *addr*
+func (in testInput) signedBy(addr sdk.AccAddress) sdk.Context {
+	acc := in.am.GetAccount(in.ctx, addr)
+	if acc == nil {
+		acc = auth.NewSHRAccountWithAddress(addr)
+	}
+	acc.SetNonce(acc.GetNonce() + 1)
+	in.am.SetAccount(in.ctx, acc)
+
+	return auth.WithSigners(in.ctx, acc)
+}
+
+// book funds the renter and books the asset, returning the booking ID
+func (in testInput) book(t *testing.T) string {
+	amt := types.NewCoin(constants.BOOKING_DENOM, 100)
+	if _, err := in.bk.AddCoin(in.ctx, testRenter, amt); err != nil {
+		t.Fatalf("Funding failed. %s", err)
+	}
+	in.bk.AddSupply(in.ctx, amt)
+
+	bk, err := in.k.bookingKeeper.Book(in.signedBy(testRenter), bookingMsg.NewMsgBook("asset", 2, testNow+testHour, testNow+3*testHour))
+	if err != nil {
+		t.Fatalf("Booking failed. %s", err)
+	}
+	return bk.BookingID
+}
+
+func TestReviewCompletedOnly(t *testing.T) {
+	in := setupTestInput(t)
+	bookingID := in.book(t)
+
+	if _, err := in.k.Review(in.signedBy(testRenter), msg.NewMsgReview(bookingID, 5, nil)); err == nil {
+		t.Errorf("Reviewing a booking which is not completed should fail.")
+	}
+
+	if _, err := in.k.bookingKeeper.Complete(in.signedBy(testOwner), bookingMsg.NewMsgComplete(bookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+
+	if _, err := in.k.Review(in.signedBy(testStranger), msg.NewMsgReview(bookingID, 1, nil)); err == nil {
+		t.Errorf("Reviewing a booking without being a party should fail.")
+	}
+
+	review, err := in.k.Review(in.signedBy(testRenter), msg.NewMsgReview(bookingID, 5, []byte("content")))
+	if err != nil {
+		t.Fatalf("Renter reviewing a completed booking failed. %s", err)
+	}
+	if !review.Subject.Equals(testOwner) {
+		t.Errorf("Renter should rate the owner, rated %s.", review.Subject)
+	}
+
+	// a renter rates the owner and the asset
+	if score := in.k.GetAccountScore(in.ctx, testOwner); score.Count != 1 || score.Sum != 5 {
+		t.Errorf("Owner should have one rating of 5, got %+v.", score)
+	}
+	if score := in.k.GetAssetScore(in.ctx, "asset"); score.Count != 1 || score.Sum != 5 {
+		t.Errorf("Asset should have one rating of 5, got %+v.", score)
+	}
+}
+
+func TestReviewOncePerParty(t *testing.T) {
+	in := setupTestInput(t)
+	bookingID := in.book(t)
+
+	if _, err := in.k.bookingKeeper.Complete(in.signedBy(testOwner), bookingMsg.NewMsgComplete(bookingID)); err != nil {
+		t.Fatalf("Completing failed. %s", err)
+	}
+
+	if _, err := in.k.Review(in.signedBy(testRenter), msg.NewMsgReview(bookingID, 4, nil)); err != nil {
+		t.Fatalf("Renter reviewing failed. %s", err)
+	}
+
+	if _, err := in.k.Review(in.signedBy(testRenter), msg.NewMsgReview(bookingID, 1, nil)); err == nil {
+		t.Errorf("Renter reviewing a booking twice should fail.")
+	}
+
+	// the other party still reviews once
+	if _, err := in.k.Review(in.signedBy(testOwner), msg.NewMsgReview(bookingID, 2, nil)); err != nil {
+		t.Fatalf("Owner reviewing failed. %s", err)
+	}
+
+	if _, err := in.k.Review(in.signedBy(testOwner), msg.NewMsgReview(bookingID, 2, nil)); err == nil {
+		t.Errorf("Owner reviewing a booking twice should fail.")
+	}
+
+	if reviews := in.k.GetReviews(in.ctx, bookingID); len(reviews) != 2 {
+		t.Errorf("Booking should have one review per party, got %d.", len(reviews))
+	}
+
+	// an owner rates the renter only, not its own asset
+	if score := in.k.GetAccountScore(in.ctx, testRenter); score.Count != 1 || score.Sum != 2 {
+		t.Errorf("Renter should have one rating of 2, got %+v.", score)
+	}
+	if score := in.k.GetAssetScore(in.ctx, "asset"); score.Count != 1 || score.Sum != 4 {
+		t.Errorf("Asset should keep the renter rating only, got %+v.", score)
+	}
+}
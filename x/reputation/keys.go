@@ -0,0 +1,40 @@
+package reputation
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	ReviewKey       = []byte{0x01} // prefix for each key to a review, by booking and reviewer
+	AccountScoreKey = []byte{0x02} // prefix for each key to the score of an account
+	AssetScoreKey   = []byte{0x03} // prefix for each key to the score of an asset
+)
+
+// gets the prefix for all reviews of a booking
+func GetReviewsPrefix(bookingID string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(bookingID)))
+	return append(append(ReviewKey, bz...), []byte(bookingID)...)
+}
+
+// gets the key for the review of a booking by one of its parties
+// VALUE: types.Review
+func GetReviewKey(bookingID string, reviewer sdk.AccAddress) []byte {
+	return append(GetReviewsPrefix(bookingID), reviewer.Bytes()...)
+}
+
+// gets the key for the score of an account
+// VALUE: types.Score
+func GetAccountScoreKey(addr sdk.AccAddress) []byte {
+	return append(AccountScoreKey, addr.Bytes()...)
+}
+
+// gets the key for the score of an asset
+// VALUE: types.Score
+func GetAssetScoreKey(uuid string) []byte {
+	return append(AssetScoreKey, []byte(uuid)...)
+}
@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sharering/shareledger/constants"
+	tags "github.com/sharering/shareledger/x/reputation/tags"
+)
+
+// MsgReview - renter or asset owner rates the other party of a completed booking
+type MsgReview struct {
+	BookingID   string `json:"bookingId"`
+	Rating      int64  `json:"rating"`
+	ContentHash []byte `json:"content_hash"` // hash of the off-chain review
+}
+
+var _ sdk.Msg = MsgReview{}
+
+func NewMsgReview(bookingId string, rating int64, contentHash []byte) MsgReview {
+	return MsgReview{
+		BookingID:   bookingId,
+		Rating:      rating,
+		ContentHash: contentHash,
+	}
+}
+
+func (msg MsgReview) Route() string {
+	return constants.MESSAGE_REPUTATION
+}
+
+func (msg MsgReview) Type() string {
+	return constants.MESSAGE_REPUTATION
+}
+
+func (msg MsgReview) ValidateBasic() sdk.Error {
+	if len(msg.BookingID) == 0 {
+		return sdk.ErrUnknownRequest("Invalid BookingID")
+	}
+
+	if msg.Rating < constants.REPUTATION_MIN_RATING || msg.Rating > constants.REPUTATION_MAX_RATING {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.REVIEW_INVALID_RATING,
+			msg.Rating,
+			constants.REPUTATION_MIN_RATING,
+			constants.REPUTATION_MAX_RATING))
+	}
+
+	if len(msg.ContentHash) == 0 || len(msg.ContentHash) > constants.REPUTATION_MAX_HASH_LENGTH {
+		return sdk.ErrUnknownRequest(fmt.Sprintf(constants.REVIEW_INVALID_HASH,
+			constants.REPUTATION_MAX_HASH_LENGTH))
+	}
+
+	return nil
+}
+
+func (msg MsgReview) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (msg MsgReview) Get(key interface{}) (value interface{}) { return nil }
+
+func (msg MsgReview) String() string {
+	return fmt.Sprintf("Reputation/MsgReview{BookingID: %s, Rating: %d, ContentHash: %X}",
+		msg.BookingID, msg.Rating, msg.ContentHash)
+}
+
+func (msg MsgReview) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{}
+}
+
+func (msg MsgReview) Tags() sdk.Tags {
+	return sdk.NewTags(tags.Event, tags.ReviewPosted).
+		AppendTag(tags.BookingId, msg.BookingID).
+		AppendTag(tags.Rating, strconv.FormatInt(msg.Rating, 10))
+}
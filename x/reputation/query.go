@@ -0,0 +1,118 @@
+package reputation
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/sharering/shareledger/constants"
+	"github.com/sharering/shareledger/types"
+)
+
+// query endpoints supported by reputation querier
+const (
+	QueryReviews = "reviews"
+	QueryAccount = "account"
+	QueryAsset   = "asset"
+)
+
+func NewQuerier(k Keeper, cdc *amino.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryReviews:
+			return queryReviews(ctx, cdc, req, k)
+		case QueryAccount:
+			return queryAccount(ctx, cdc, req, k)
+		case QueryAsset:
+			return queryAsset(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown reputation query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/reputation/reviews'
+type QueryReviewsParams struct {
+	BookingID string
+}
+
+// defines the params for the following queries:
+// - 'custom/reputation/account'
+type QueryAccountParams struct {
+	Address sdk.AccAddress
+}
+
+// defines the params for the following queries:
+// - 'custom/reputation/asset'
+type QueryAssetParams struct {
+	UUID string
+}
+
+// Reputation - result of 'custom/reputation/account' and 'custom/reputation/asset'
+type Reputation struct {
+	Count   int64     `json:"count"`
+	Sum     int64     `json:"sum"`
+	Average types.Dec `json:"average"`
+}
+
+func NewReputation(score types.Score) Reputation {
+	return Reputation{
+		Count:   score.Count,
+		Sum:     score.Sum,
+		Average: score.Average(),
+	}
+}
+
+func queryReviews(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryReviewsParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryReviewsParams"))
+	}
+
+	// reviews are kept under the full booking ID, legacy short IDs resolve to it
+	bookingID := params.BookingID
+	if bk, found := k.bookingKeeper.GetBooking(ctx, params.BookingID); found {
+		bookingID = bk.BookingID
+	}
+
+	res, errRes = cdc.MarshalJSON(k.GetReviews(ctx, bookingID))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "[]types.Review"))
+	}
+	return res, nil
+}
+
+func queryAccount(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryAccountParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryAccountParams"))
+	}
+
+	res, errRes = cdc.MarshalJSON(NewReputation(k.GetAccountScore(ctx, params.Address)))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "Reputation"))
+	}
+	return res, nil
+}
+
+func queryAsset(ctx sdk.Context, cdc *amino.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryAssetParams
+
+	errRes := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf(constants.ERROR_DECODING, "QueryAssetParams"))
+	}
+
+	res, errRes = cdc.MarshalJSON(NewReputation(k.GetAssetScore(ctx, params.UUID)))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf(constants.ERROR_ENCODING, "Reputation"))
+	}
+	return res, nil
+}
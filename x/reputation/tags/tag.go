@@ -0,0 +1,16 @@
+package tags
+
+var (
+	//Key - String type
+
+	Event     = "Event"
+	BookingId = "BookingId"
+	UUID      = "UUID"
+	Reviewer  = "Reviewer"
+	Subject   = "Subject"
+	Rating    = "Rating"
+
+	//Value -  []byte
+
+	ReviewPosted = "ReviewPosted"
+)